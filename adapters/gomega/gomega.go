@@ -0,0 +1,123 @@
+// Package gomega provides Gomega matchers for Gopactor assertions.
+// The actual value of every matcher is the PID of an actor
+// spawned by the default Gopactor.
+//
+// Example:
+//
+//   worker, _ := gopactor.SpawnFromInstance(&Worker{})
+//   worker.Request("ping", requestor)
+//
+//   Expect(worker).To(gopactor.ReceiveFrom(requestor, "ping"))
+//   Eventually(worker).Should(gopactor.Send("pong"))
+//   Consistently(worker).ShouldNot(gopactor.Spawn(""))
+//
+// Every match consumes one intercepted message, the same way assertions do.
+// Thus, when polled by Eventually, a matcher skips messages which do not match
+// until the expected one arrives.
+package gomega
+
+import (
+	"fmt"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/gopactor"
+	"github.com/meamidos/gopactor/matchers"
+	"github.com/onsi/gomega/types"
+)
+
+// Receive succeeds if the actor receives a given message.
+// A nil message means any message.
+func Receive(msg interface{}) types.GomegaMatcher {
+	return newMatcher("receive "+describe(msg), func(e *gopactor.Expectation) *gopactor.Expectation {
+		return e.ToReceive(msg)
+	})
+}
+
+// ReceiveFrom succeeds if the actor receives a given message from a given sender.
+func ReceiveFrom(sender *actor.PID, msg interface{}) types.GomegaMatcher {
+	return newMatcher(fmt.Sprintf("receive %s from %v", describe(msg), sender), func(e *gopactor.Expectation) *gopactor.Expectation {
+		return e.ToReceive(msg).From(sender)
+	})
+}
+
+// Send succeeds if the actor sends a given message.
+// A nil message means any message.
+func Send(msg interface{}) types.GomegaMatcher {
+	return newMatcher("send "+describe(msg), func(e *gopactor.Expectation) *gopactor.Expectation {
+		return e.ToSend(msg)
+	})
+}
+
+// SendTo succeeds if the actor sends a given message to a given receiver.
+func SendTo(receiver *actor.PID, msg interface{}) types.GomegaMatcher {
+	return newMatcher(fmt.Sprintf("send %s to %v", describe(msg), receiver), func(e *gopactor.Expectation) *gopactor.Expectation {
+		return e.ToSend(msg).To(receiver)
+	})
+}
+
+// Spawn succeeds if the actor spawns a child whose PID contains a given substring.
+// An empty string means any child.
+func Spawn(match string) types.GomegaMatcher {
+	return newMatcher(fmt.Sprintf("spawn a child matching %q", match), func(e *gopactor.Expectation) *gopactor.Expectation {
+		return e.ToSpawn(match)
+	})
+}
+
+// Start succeeds if the actor is started.
+func Start() types.GomegaMatcher {
+	return newMatcher("start", func(e *gopactor.Expectation) *gopactor.Expectation {
+		return e.ToStart()
+	})
+}
+
+// Stop succeeds if the actor is stopped.
+func Stop() types.GomegaMatcher {
+	return newMatcher("stop", func(e *gopactor.Expectation) *gopactor.Expectation {
+		return e.ToStop()
+	})
+}
+
+// Fail succeeds if the actor panics. A nil reason means any reason.
+func Fail(reason interface{}) types.GomegaMatcher {
+	return newMatcher("fail", func(e *gopactor.Expectation) *gopactor.Expectation {
+		return e.ToFail(reason)
+	})
+}
+
+type matcher struct {
+	description string
+	expect      func(e *gopactor.Expectation) *gopactor.Expectation
+
+	// The failure of the last match, if any
+	failure error
+}
+
+func newMatcher(description string, expect func(e *gopactor.Expectation) *gopactor.Expectation) *matcher {
+	return &matcher{description: description, expect: expect}
+}
+
+func (m *matcher) Match(actual interface{}) (bool, error) {
+	pid, ok := actual.(*actor.PID)
+	if !ok {
+		return false, fmt.Errorf("Expected an actor PID, got %#v", actual)
+	}
+
+	m.failure = m.expect(gopactor.DEFAULT_GOPACTOR.Expect(pid)).Verify()
+	return m.failure == nil, nil
+}
+
+func (m *matcher) FailureMessage(actual interface{}) string {
+	return fmt.Sprintf("Expected %v to %s\n%v", actual, m.description, m.failure)
+}
+
+func (m *matcher) NegatedFailureMessage(actual interface{}) string {
+	return fmt.Sprintf("Expected %v not to %s", actual, m.description)
+}
+
+func describe(msg interface{}) string {
+	if msg == nil {
+		return "any message"
+	}
+
+	return matchers.Describe(msg)
+}
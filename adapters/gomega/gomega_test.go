@@ -0,0 +1,65 @@
+package gomega_test
+
+import (
+	"testing"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	. "github.com/meamidos/gopactor/adapters/gomega"
+	"github.com/meamidos/gopactor/gopactor"
+	"github.com/meamidos/gopactor/options"
+	"github.com/onsi/gomega/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchers(t *testing.T) {
+	a := assert.New(t)
+	p := gopactor.DEFAULT_GOPACTOR
+
+	match := func(m types.GomegaMatcher, actual interface{}) bool {
+		ok, err := m.Match(actual)
+		a.Nil(err)
+		return ok
+	}
+
+	requestor, _ := p.SpawnNullActor()
+	worker, _ := p.SpawnFromFunc(func(ctx actor.Context) {
+		switch ctx.Message() {
+		case "ping":
+			ctx.Respond("pong")
+		case "spawn":
+			ctx.SpawnPrefix(actor.FromFunc(func(actor.Context) {}), "child")
+		}
+	}, options.OptDefault.WithSpawnInterception())
+
+	// Success
+	worker.Request("ping", requestor)
+	a.True(match(ReceiveFrom(requestor, "ping"), worker))
+	a.True(match(Send("pong"), worker))
+
+	worker.Tell("spawn")
+	a.True(match(Receive(nil), worker))
+	a.True(match(Spawn("child"), worker))
+
+	// Polling skips messages which do not match, like Eventually does
+	worker.Tell(1)
+	worker.Tell(2)
+	m := Receive(2)
+	a.False(match(m, worker))
+	a.True(match(m, worker))
+	a.False(match(Receive(nil), worker))
+
+	// Failure messages
+	m = Receive("hello")
+	worker.Tell("ping")
+	a.False(match(m, worker))
+	a.Contains(m.FailureMessage(worker), `to receive "hello"`)
+	a.Contains(m.FailureMessage(worker), "Messages do not match")
+	a.Contains(m.NegatedFailureMessage(worker), `not to receive "hello"`)
+
+	// Not a PID
+	_, err := m.Match("worker")
+	a.NotNil(err)
+
+	// Cleanup
+	p.Reset()
+}
@@ -0,0 +1,112 @@
+// Package testify adapts Gopactor assertions to testify.
+// Every function reports a failure via assert.TestingT
+// and returns true if the assertion holds, just like
+// the functions of testify's assert package do.
+//
+// Example:
+//
+//   func TestWorker(t *testing.T) {
+//       worker, _ := gopactor.SpawnFromInstance(&Worker{})
+//       worker.Request("ping", requestor)
+//
+//       testify.AssertReceiveFrom(t, worker, requestor, "ping")
+//       testify.AssertSendTo(t, worker, requestor, "pong")
+//   }
+//
+// All functions work with actors spawned by the default Gopactor.
+package testify
+
+import (
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/gopactor"
+	"github.com/stretchr/testify/assert"
+)
+
+// AssertReceive asserts that the actor receives a given message.
+// A nil message means any message.
+func AssertReceive(t assert.TestingT, pid *actor.PID, msg interface{}, msgAndArgs ...interface{}) bool {
+	return check(t, expect(pid).ToReceive(msg).Verify(), msgAndArgs...)
+}
+
+// AssertReceiveFrom asserts that the actor receives a given message from a given sender.
+func AssertReceiveFrom(t assert.TestingT, pid, sender *actor.PID, msg interface{}, msgAndArgs ...interface{}) bool {
+	return check(t, expect(pid).ToReceive(msg).From(sender).Verify(), msgAndArgs...)
+}
+
+// AssertNotReceive asserts that the actor does not receive a given message within the timeout.
+// A nil message means any message.
+func AssertNotReceive(t assert.TestingT, pid *actor.PID, msg interface{}, msgAndArgs ...interface{}) bool {
+	return check(t, expect(pid).NotToReceive(msg).Verify(), msgAndArgs...)
+}
+
+// AssertSend asserts that the actor sends a given message.
+// A nil message means any message.
+func AssertSend(t assert.TestingT, pid *actor.PID, msg interface{}, msgAndArgs ...interface{}) bool {
+	return check(t, expect(pid).ToSend(msg).Verify(), msgAndArgs...)
+}
+
+// AssertSendTo asserts that the actor sends a given message to a given receiver.
+func AssertSendTo(t assert.TestingT, pid, receiver *actor.PID, msg interface{}, msgAndArgs ...interface{}) bool {
+	return check(t, expect(pid).ToSend(msg).To(receiver).Verify(), msgAndArgs...)
+}
+
+// AssertNotSend asserts that the actor does not send a given message within the timeout.
+// A nil message means any message.
+func AssertNotSend(t assert.TestingT, pid *actor.PID, msg interface{}, msgAndArgs ...interface{}) bool {
+	return check(t, expect(pid).NotToSend(msg).Verify(), msgAndArgs...)
+}
+
+// AssertNoTraffic asserts that the actor neither sends nor receives anything within the timeout.
+func AssertNoTraffic(t assert.TestingT, pid *actor.PID, msgAndArgs ...interface{}) bool {
+	return check(t, expect(pid).NotToSendOrReceive().Verify(), msgAndArgs...)
+}
+
+// AssertSpawn asserts that the actor spawns a child whose PID contains a given substring.
+// An empty string means any child.
+func AssertSpawn(t assert.TestingT, pid *actor.PID, match string, msgAndArgs ...interface{}) bool {
+	return check(t, expect(pid).ToSpawn(match).Verify(), msgAndArgs...)
+}
+
+// AssertNotSpawn asserts that the actor does not spawn a child whose PID contains
+// a given substring within the timeout. An empty string means any child.
+func AssertNotSpawn(t assert.TestingT, pid *actor.PID, match string, msgAndArgs ...interface{}) bool {
+	return check(t, expect(pid).NotToSpawn(match).Verify(), msgAndArgs...)
+}
+
+// AssertStart asserts that the actor is started.
+func AssertStart(t assert.TestingT, pid *actor.PID, msgAndArgs ...interface{}) bool {
+	return check(t, expect(pid).ToStart().Verify(), msgAndArgs...)
+}
+
+// AssertStop asserts that the actor is stopped.
+func AssertStop(t assert.TestingT, pid *actor.PID, msgAndArgs ...interface{}) bool {
+	return check(t, expect(pid).ToStop().Verify(), msgAndArgs...)
+}
+
+// AssertFail asserts that the actor panics. A nil reason means any reason.
+func AssertFail(t assert.TestingT, pid *actor.PID, reason interface{}, msgAndArgs ...interface{}) bool {
+	return check(t, expect(pid).ToFail(reason).Verify(), msgAndArgs...)
+}
+
+// AssertNotFail asserts that the actor does not panic within the timeout.
+func AssertNotFail(t assert.TestingT, pid *actor.PID, msgAndArgs ...interface{}) bool {
+	return check(t, expect(pid).NotToFail().Verify(), msgAndArgs...)
+}
+
+func expect(pid *actor.PID) *gopactor.Expectation {
+	return gopactor.DEFAULT_GOPACTOR.Expect(pid)
+}
+
+func check(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(interface {
+		Helper()
+	}); ok {
+		h.Helper()
+	}
+
+	if err != nil {
+		return assert.Fail(t, err.Error(), msgAndArgs...)
+	}
+
+	return true
+}
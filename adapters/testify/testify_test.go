@@ -0,0 +1,55 @@
+package testify_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/adapters/testify"
+	"github.com/meamidos/gopactor/gopactor"
+	"github.com/stretchr/testify/assert"
+)
+
+// Records failures instead of failing the test
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAdapter(t *testing.T) {
+	a := assert.New(t)
+	p := gopactor.DEFAULT_GOPACTOR
+
+	requestor, _ := p.SpawnNullActor()
+	worker, _ := p.SpawnFromFunc(func(ctx actor.Context) {
+		if ctx.Message() == "ping" {
+			ctx.Respond("pong")
+		}
+	})
+
+	// Success
+	r := &recorder{}
+	worker.Request("ping", requestor)
+	a.True(testify.AssertReceiveFrom(r, worker, requestor, "ping"))
+	a.True(testify.AssertSend(r, worker, "pong"))
+	a.True(testify.AssertNoTraffic(r, worker))
+	a.True(testify.AssertNotSpawn(r, worker, ""))
+	a.Empty(r.errors)
+
+	// Failure
+	worker.Tell("ping")
+	a.False(testify.AssertReceive(r, worker, "hello", "custom %s", "message"))
+	a.Len(r.errors, 1)
+	a.Contains(r.errors[0], "Messages do not match")
+	a.Contains(r.errors[0], "custom message")
+
+	a.False(testify.AssertSendTo(r, worker, requestor, "pong"))
+	a.Len(r.errors, 2)
+	a.Contains(r.errors[1], "Receiver does not match")
+
+	// Cleanup
+	p.Reset()
+}
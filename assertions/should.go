@@ -20,6 +20,29 @@ func ShouldReceiveFrom(actual interface{}, expected ...interface{}) string {
 	return gopactor.DEFAULT_GOPACTOR.ShouldReceiveFrom(actual, expected...)
 }
 
+// ShouldReceiveAll asserts that all given messages are received by the actor
+// in any order. Other messages received in the meantime are ignored:
+//   So(coordinator, ShouldReceiveAll, MsgFrom(worker1, "done"), MsgFrom(worker2, "done"))
+func ShouldReceiveAll(actual interface{}, expected ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldReceiveAll(actual, expected...)
+}
+
+// ShouldEventuallyReceive asserts that a given message is received by the actor
+// sooner or later. Other messages are discarded, unless KeepSkipped is given.
+// A custom timeout can be given as well:
+//   So(myActor, ShouldEventuallyReceive, "ping")
+//   So(myActor, ShouldEventuallyReceive, "ping", time.Second, KeepSkipped)
+func ShouldEventuallyReceive(actual interface{}, expected ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldEventuallyReceive(actual, expected...)
+}
+
+// ShouldConsistentlyNotReceive asserts that a given message is not received
+// by the actor during the whole window. Other messages are discarded:
+//   So(myActor, ShouldConsistentlyNotReceive, "ping", 50*time.Millisecond)
+func ShouldConsistentlyNotReceive(actual interface{}, expected ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldConsistentlyNotReceive(actual, expected...)
+}
+
 // ShouldReceiveSomething asserts that some message is received by the actor
 // and it does not matter who is the sender
 // and what is in the message:
@@ -50,6 +73,28 @@ func ShouldSendTo(actual interface{}, expected ...interface{}) string {
 	return gopactor.DEFAULT_GOPACTOR.ShouldSendTo(actual, expected...)
 }
 
+// ShouldEventuallySend asserts that a given message is sent by the actor
+// sooner or later. Other messages are discarded, unless KeepSkipped is given:
+//   So(myActor, ShouldEventuallySend, "pong")
+//   So(myActor, ShouldEventuallySend, "pong", KeepSkipped)
+func ShouldEventuallySend(actual interface{}, expected ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldEventuallySend(actual, expected...)
+}
+
+// ShouldSendAll asserts that all given messages are sent by the actor
+// in any order. Other messages sent in the meantime are ignored:
+//   So(coordinator, ShouldSendAll, MsgTo(worker1, "job"), MsgTo(worker2, "job"))
+func ShouldSendAll(actual interface{}, expected ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldSendAll(actual, expected...)
+}
+
+// ShouldSendInAnyOrder asserts that exactly the given messages are sent
+// by the actor in any order. Any other message fails the assertion:
+//   So(coordinator, ShouldSendInAnyOrder, "job-1", "job-2", "job-3")
+func ShouldSendInAnyOrder(actual interface{}, expected ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldSendInAnyOrder(actual, expected...)
+}
+
 // ShouldSendSomething asserts that some message is sent by the actor
 // and it does not matter who is the receiver
 // and what is in the message:
@@ -74,6 +119,43 @@ func ShouldNotSendOrReceive(actual interface{}, _ ...interface{}) string {
 	return gopactor.DEFAULT_GOPACTOR.ShouldNotSendOrReceive(actual)
 }
 
+// ShouldNotReceive asserts that the actor does not receive a given message
+// during the timeout. Other messages are allowed. Without a message,
+// the actor should not receive anything at all:
+//   So(myActor, ShouldNotReceive, "ping")
+//   So(myActor, ShouldNotReceive, matchers.OfType(&Ping{}))
+//   So(myActor, ShouldNotReceive)
+func ShouldNotReceive(actual interface{}, params ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldNotReceive(actual, params...)
+}
+
+// ShouldNotReceiveFrom asserts that the actor does not receive a given message
+// from a certain sender during the timeout. Without a message,
+// the actor should not receive anything from the sender:
+//   So(myActor, ShouldNotReceiveFrom, sender, "ping")
+//   So(myActor, ShouldNotReceiveFrom, sender)
+func ShouldNotReceiveFrom(actual interface{}, params ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldNotReceiveFrom(actual, params...)
+}
+
+// ShouldNotSend asserts that the actor does not send a given message
+// during the timeout. Other messages are allowed. Without a message,
+// the actor should not send anything at all:
+//   So(myActor, ShouldNotSend, "ping")
+//   So(myActor, ShouldNotSend)
+func ShouldNotSend(actual interface{}, params ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldNotSend(actual, params...)
+}
+
+// ShouldNotSendTo asserts that the actor does not send a given message
+// to a certain receiver during the timeout. Without a message,
+// the actor should not send anything to the receiver:
+//   So(myActor, ShouldNotSendTo, receiver, "ping")
+//   So(myActor, ShouldNotSendTo, receiver)
+func ShouldNotSendTo(actual interface{}, params ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldNotSendTo(actual, params...)
+}
+
 // ShouldStart asserts that the actor has formally started.
 // That is, it has received the &actor.Started{} message.
 //   So(myActor, ShouldStart)
@@ -87,12 +169,127 @@ func ShouldStop(actual interface{}, _ ...interface{}) string {
 	return gopactor.DEFAULT_GOPACTOR.ShouldStop(actual)
 }
 
+// ShouldNotStop asserts that the actor does not stop during the timeout.
+//   So(myActor, ShouldNotStop)
+func ShouldNotStop(actual interface{}, _ ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldNotStop(actual)
+}
+
 // ShouldBeRestarting asserts that the actor is restarting
 //   So(myActor, ShouldBeRestarting)
 func ShouldBeRestarting(actual interface{}, _ ...interface{}) string {
 	return gopactor.DEFAULT_GOPACTOR.ShouldBeRestarting(actual)
 }
 
+// ShouldFail asserts that the actor panics while handling a message.
+// The failure is recorded right before Protoactor escalates it
+// to the actor's supervisor. System interception should be enabled.
+//   So(myActor, ShouldFail)
+func ShouldFail(actual interface{}, _ ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldFail(actual)
+}
+
+// ShouldFailWith asserts that the actor panics with a given reason.
+// The reason can be a matcher.
+//   So(myActor, ShouldFailWith, "out of coffee")
+//   So(myActor, ShouldFailWith, matchers.Regexp("coffee"))
+func ShouldFailWith(actual interface{}, params ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldFailWith(actual, params...)
+}
+
+// ShouldNotFail asserts that the actor does not panic during the timeout.
+//   So(myActor, ShouldNotFail)
+func ShouldNotFail(actual interface{}, _ ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldNotFail(actual)
+}
+
+// ShouldResumeChild asserts that the supervisor strategy of the actor
+// decides to resume a failed child. The actor should be spawned
+// with supervision recording enabled.
+//   So(myActor, ShouldResumeChild)
+//   So(myActor, ShouldResumeChild, childPID)
+func ShouldResumeChild(actual interface{}, params ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldResumeChild(actual, params...)
+}
+
+// ShouldRestartChild asserts that the supervisor strategy of the actor
+// decides to restart a failed child.
+//   So(myActor, ShouldRestartChild)
+//   So(myActor, ShouldRestartChild, childPID)
+func ShouldRestartChild(actual interface{}, params ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldRestartChild(actual, params...)
+}
+
+// ShouldStopChild asserts that the supervisor strategy of the actor
+// decides to stop a failed child.
+//   So(myActor, ShouldStopChild)
+//   So(myActor, ShouldStopChild, childPID)
+func ShouldStopChild(actual interface{}, params ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldStopChild(actual, params...)
+}
+
+// ShouldEscalate asserts that the supervisor strategy of the actor
+// decides to escalate a failure of a child.
+//   So(myActor, ShouldEscalate)
+//   So(myActor, ShouldEscalate, childPID)
+func ShouldEscalate(actual interface{}, params ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldEscalate(actual, params...)
+}
+
+// ShouldBeStopping asserts that the actor is about to stop.
+//   So(myActor, ShouldBeStopping)
+func ShouldBeStopping(actual interface{}, _ ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldBeStopping(actual)
+}
+
+// ShouldReceiveRestart asserts that the actor is told to restart by its supervisor.
+//   So(myActor, ShouldReceiveRestart)
+func ShouldReceiveRestart(actual interface{}, _ ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldReceiveRestart(actual)
+}
+
+// ShouldReceiveTimeout asserts that the receive timeout of the actor expires.
+//   So(myActor, ShouldReceiveTimeout)
+func ShouldReceiveTimeout(actual interface{}, _ ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldReceiveTimeout(actual)
+}
+
+// ShouldBePoisoned asserts that the actor receives a PoisonPill.
+//   So(myActor, ShouldBePoisoned)
+func ShouldBePoisoned(actual interface{}, _ ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldBePoisoned(actual)
+}
+
+// ShouldBeWatched asserts that another actor starts watching the actor.
+// The watcher should be spawned by Gopactor.
+//   So(myActor, ShouldBeWatched)
+//   So(myActor, ShouldBeWatched, watcherPID)
+func ShouldBeWatched(actual interface{}, params ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldBeWatched(actual, params...)
+}
+
+// ShouldBeUnwatched asserts that another actor stops watching the actor.
+// The watcher should be spawned by Gopactor.
+//   So(myActor, ShouldBeUnwatched, watcherPID)
+func ShouldBeUnwatched(actual interface{}, params ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldBeUnwatched(actual, params...)
+}
+
+// ShouldObserveFailure asserts that a child of the actor fails.
+//   So(myActor, ShouldObserveFailure)
+//   So(myActor, ShouldObserveFailure, childPID)
+func ShouldObserveFailure(actual interface{}, params ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldObserveFailure(actual, params...)
+}
+
+// ShouldReceiveSystem asserts that the actor receives a given system message.
+// Other system messages are skipped. The message can be a matcher:
+//   So(myActor, ShouldReceiveSystem, &actor.Stopping{})
+//   So(myActor, ShouldReceiveSystem, MatchType(&actor.Terminated{}))
+func ShouldReceiveSystem(actual interface{}, params ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldReceiveSystem(actual, params...)
+}
+
 // ShouldObserveTermination asserts that the actor is notified when another actor is terminated.
 //   So(myActor, ShouldObserveTermination)
 //   So(myActor, ShouldObserveTermination, anotherActorPID)
@@ -100,9 +297,57 @@ func ShouldObserveTermination(actual interface{}, params ...interface{}) string
 	return gopactor.DEFAULT_GOPACTOR.ShouldObserveTermination(actual, params...)
 }
 
+// ShouldStopActor asserts that the actor stops another actor.
+// It requires outbound system interception.
+//   So(myActor, ShouldStopActor)
+//   So(myActor, ShouldStopActor, anotherActorPID)
+func ShouldStopActor(actual interface{}, params ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldStopActor(actual, params...)
+}
+
+// ShouldWatch asserts that the actor starts watching another actor.
+// It requires outbound system interception.
+//   So(myActor, ShouldWatch, anotherActorPID)
+func ShouldWatch(actual interface{}, params ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldWatch(actual, params...)
+}
+
+// ShouldUnwatch asserts that the actor stops watching another actor.
+// It requires outbound system interception.
+//   So(myActor, ShouldUnwatch, anotherActorPID)
+func ShouldUnwatch(actual interface{}, params ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldUnwatch(actual, params...)
+}
+
+// ShouldPoison asserts that the actor sends a PoisonPill to another actor.
+// It requires outbound system interception.
+//   So(myActor, ShouldPoison, anotherActorPID)
+func ShouldPoison(actual interface{}, params ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldPoison(actual, params...)
+}
+
+// ShouldMatchConversationSnapshot asserts that the conversation recorded
+// by one or more actors matches a golden file under testdata/.
+// Fields with volatile values can be redacted by name.
+// Run the tests with -update to regenerate the golden files:
+//   So(myActor, ShouldMatchConversationSnapshot, "login")
+//   So([]*actor.PID{client, server}, ShouldMatchConversationSnapshot, "login", "SessionID")
+func ShouldMatchConversationSnapshot(actual interface{}, params ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldMatchConversationSnapshot(actual, params...)
+}
+
 // ShouldSpawn asserts that the actor spawns a child
 //   So(myActor, ShouldSpawn, "my-child")
 //   So(myActor, ShouldSpawn)
 func ShouldSpawn(actual interface{}, params ...interface{}) string {
 	return gopactor.DEFAULT_GOPACTOR.ShouldSpawn(actual, params...)
 }
+
+// ShouldNotSpawn asserts that the actor does not spawn a child
+// during the timeout. With a substring, only the children whose PIDs
+// contain it are forbidden:
+//   So(myActor, ShouldNotSpawn, "my-child")
+//   So(myActor, ShouldNotSpawn)
+func ShouldNotSpawn(actual interface{}, params ...interface{}) string {
+	return gopactor.DEFAULT_GOPACTOR.ShouldNotSpawn(actual, params...)
+}
@@ -0,0 +1,23 @@
+package gopactor
+
+import (
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/catcher"
+	"github.com/meamidos/gopactor/gopactor"
+)
+
+// CaptureReceived waits for the next message received by the actor
+// and stores it into the target, which must be a pointer to a variable
+// of the message type. It is handy when a test needs the actual message,
+// e.g. to extract a generated ID from it:
+//   var resp *Response
+//   envelope, err := CaptureReceived(worker, &resp)
+func CaptureReceived(pid *actor.PID, target interface{}) (*catcher.Envelope, error) {
+	return gopactor.DEFAULT_GOPACTOR.CaptureReceived(pid, target)
+}
+
+// CaptureSent waits for the next message sent by the actor
+// and stores it into the target, just like CaptureReceived does.
+func CaptureSent(pid *actor.PID, target interface{}) (*catcher.Envelope, error) {
+	return gopactor.DEFAULT_GOPACTOR.CaptureSent(pid, target)
+}
@@ -0,0 +1,23 @@
+//go:build go1.18
+// +build go1.18
+
+package gopactor
+
+import (
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/catcher"
+	"github.com/meamidos/gopactor/gopactor"
+)
+
+// ReceiveAs waits for the next message received by the actor
+// and returns it typed, along with the envelope:
+//   resp, envelope, err := ReceiveAs[*Response](worker)
+func ReceiveAs[T any](pid *actor.PID) (T, catcher.Envelope, error) {
+	return gopactor.ReceiveAs[T](gopactor.DEFAULT_GOPACTOR, pid)
+}
+
+// SentAs waits for the next message sent by the actor
+// and returns it typed, along with the envelope.
+func SentAs[T any](pid *actor.PID) (T, catcher.Envelope, error) {
+	return gopactor.SentAs[T](gopactor.DEFAULT_GOPACTOR, pid)
+}
@@ -0,0 +1,42 @@
+//go:build go1.18
+// +build go1.18
+
+package gopactor
+
+import (
+	"testing"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaptureGeneric(t *testing.T) {
+	a := assert.New(t)
+
+	requestor, _ := SpawnNullActor(OptNoInterception)
+	worker, _ := SpawnFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(string); ok {
+			ctx.Respond(&Created{ID: "42"})
+		}
+	})
+
+	worker.Request("create", requestor)
+	request, envelope, err := ReceiveAs[string](worker)
+	a.Nil(err)
+	a.Equal("create", request)
+	a.Equal(requestor, envelope.Sender)
+
+	created, envelope, err := SentAs[*Created](worker)
+	a.Nil(err)
+	a.Equal("42", created.ID)
+	a.Equal(requestor, envelope.Target)
+
+	// The envelope is returned even if the type does not match
+	worker.Request("create", requestor)
+	_, envelope, err = ReceiveAs[*Created](worker)
+	a.Contains(err.Error(), "Message type does not match")
+	a.Equal("create", envelope.Message)
+
+	// Cleanup
+	PactReset()
+}
@@ -0,0 +1,62 @@
+package gopactor
+
+import (
+	"testing"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+type Created struct {
+	ID string
+}
+
+func TestCapture(t *testing.T) {
+	a := assert.New(t)
+
+	requestor, _ := SpawnNullActor(OptNoInterception)
+	worker, _ := SpawnFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(string); ok {
+			ctx.Respond(&Created{ID: "42"})
+		}
+	})
+
+	// Wrong params
+	_, err := CaptureReceived(worker, nil)
+	a.Contains(err.Error(), "non-nil pointer")
+	_, err = CaptureSent(actor.NewLocalPID("unknown"), new(string))
+	a.Contains(err.Error(), "not registered")
+
+	// Capture the received message
+	var request string
+	worker.Request("create", requestor)
+	envelope, err := CaptureReceived(worker, &request)
+	a.Nil(err)
+	a.Equal("create", request)
+	a.Equal(requestor, envelope.Sender)
+
+	// Capture the sent message
+	var created *Created
+	envelope, err = CaptureSent(worker, &created)
+	a.Nil(err)
+	a.Equal("42", created.ID)
+	a.Equal(requestor, envelope.Target)
+
+	// Interfaces are fine
+	var any interface{}
+	worker.Tell("create")
+	_, err = CaptureReceived(worker, &any)
+	a.Nil(err)
+	a.Equal("create", any)
+
+	// Type mismatch
+	_, err = CaptureSent(worker, &request)
+	a.Contains(err.Error(), "Message type does not match")
+
+	// Timeout
+	_, err = CaptureSent(worker, &created)
+	a.Contains(err.Error(), "Timeout")
+
+	// Cleanup
+	PactReset()
+}
@@ -2,10 +2,10 @@ package catcher
 
 import (
 	"fmt"
-	"reflect"
 	"strings"
 
 	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/matchers"
 )
 
 func messagesMatch(actual, expected interface{}) bool {
@@ -22,61 +22,144 @@ func messagesMatch(actual, expected interface{}) bool {
 		}
 	}
 
-	return reflect.DeepEqual(actual, expected)
+	// Special case: compare Watch and Unwatch messages
+	// A nil watcher means any watcher.
+	if watchActual, ok := actual.(*actor.Watch); ok {
+		if watchExpected, ok := expected.(*actor.Watch); ok {
+			return watchExpected.Watcher == nil || watchExpected.Watcher.Equal(watchActual.Watcher)
+		}
+	}
+	if unwatchActual, ok := actual.(*actor.Unwatch); ok {
+		if unwatchExpected, ok := expected.(*actor.Unwatch); ok {
+			return unwatchExpected.Watcher == nil || unwatchExpected.Watcher.Equal(unwatchActual.Watcher)
+		}
+	}
+
+	// Special case: compare Failure messages
+	// Only the failed actor and the reason are taken into account, if given.
+	if failureActual, ok := actual.(*actor.Failure); ok {
+		if failureExpected, ok := expected.(*actor.Failure); ok {
+			if failureExpected.Who != nil && !failureExpected.Who.Equal(failureActual.Who) {
+				return false
+			}
+
+			// Any reason will suffice
+			if failureExpected.Reason == nil {
+				return true
+			}
+
+			return matchers.Match(failureActual.Reason, failureExpected.Reason)
+		}
+	}
+
+	// Matchers decide for themselves, everything else is compared as is
+	return matchers.Match(actual, expected)
 }
 
-func assertInboundMessage(envelope *Envelope, msg interface{}, sender *actor.PID) string {
+func assertInboundMessage(envelope *Envelope, msg interface{}, sender *actor.PID) error {
 	if !messagesMatch(envelope.Message, msg) {
-		return fmt.Sprintf(`
-Messages do not match
-Expected: %#v
-Actual: %#v
-`, msg, envelope.Message)
+		return &Failure{
+			Reason:   "Messages do not match",
+			Expected: matchers.Describe(msg),
+			Actual:   fmt.Sprintf("%#v", envelope.Message),
+			Envelope: envelope,
+		}
 	}
 
 	if sender != nil {
 		if envelope.Sender == nil {
-			return fmt.Sprintf(`
-Sender is unknown
-Expected: %#v
-Actual: nil
-`, sender)
+			return &Failure{
+				Reason:   "Sender is unknown",
+				Expected: fmt.Sprintf("%#v", sender),
+				Actual:   "nil",
+				Envelope: envelope,
+			}
 		} else if !sender.Equal(envelope.Sender) {
-			return fmt.Sprintf(`
-Sender does not match
-Expected: %#v
-Actual: %#v
-`, sender, envelope.Sender)
+			return &Failure{
+				Reason:   "Sender does not match",
+				Expected: fmt.Sprintf("%#v", sender),
+				Actual:   fmt.Sprintf("%#v", envelope.Sender),
+				Envelope: envelope,
+			}
 		}
 	}
 
-	return ""
+	return nil
 }
 
-func assertOutboundMessage(envelope *Envelope, msg interface{}, receiver *actor.PID) string {
+func assertOutboundMessage(envelope *Envelope, msg interface{}, receiver *actor.PID) error {
 	if !messagesMatch(envelope.Message, msg) {
-		return fmt.Sprintf(`
-Messages do not match
-Expected: %#v
-Actual: %#v
-`, msg, envelope.Message)
+		return &Failure{
+			Reason:   "Messages do not match",
+			Expected: matchers.Describe(msg),
+			Actual:   fmt.Sprintf("%#v", envelope.Message),
+			Envelope: envelope,
+		}
 	}
 
 	if receiver != nil && !receiver.Equal(envelope.Target) {
-		return "Receiver does not match"
+		return &Failure{
+			Reason:   "Receiver does not match",
+			Expected: fmt.Sprintf("%v", receiver),
+			Actual:   fmt.Sprintf("%v", envelope.Target),
+			Envelope: envelope,
+		}
 	}
 
-	return ""
+	return nil
 }
 
-func assertSpawnedActor(pid *actor.PID, match string) string {
-	if !strings.Contains(pid.String(), match) {
-		return fmt.Sprintf(`
-The spawned actor's PID does not match
-Expected: %s
-Actual: %s
-`, match, pid)
+func assertSpawnedActor(envelope *Envelope, match string) error {
+	if !strings.Contains(envelope.Target.String(), match) {
+		return &Failure{
+			Reason:   "The spawned actor's PID does not match",
+			Expected: match,
+			Actual:   envelope.Target.String(),
+			Envelope: envelope,
+		}
+	}
+
+	return nil
+}
+
+// envelopeMatches tells whether an envelope satisfies all given conditions.
+// Empty conditions (nil values) are ignored.
+func envelopeMatches(envelope *Envelope, msg interface{}, sender, target *actor.PID) bool {
+	if msg != nil && !messagesMatch(envelope.Message, msg) {
+		return false
+	}
+
+	if sender != nil && !sender.Equal(envelope.Sender) {
+		return false
+	}
+
+	if target != nil && !target.Equal(envelope.Target) {
+		return false
+	}
+
+	return true
+}
+
+func assertDecision(envelope *Envelope, child *actor.PID, directive actor.Directive) error {
+	decision := envelope.Message.(*Decision)
+
+	if decision.Directive != directive {
+		return &Failure{
+			Reason:   "Supervisor directive does not match",
+			Expected: DirectiveName(directive),
+			Actual:   decision.String(),
+			Envelope: envelope,
+		}
+	}
+
+	if child != nil && !child.Equal(decision.Child) {
+		return &Failure{
+			Reason:   "Failed child does not match",
+			Expected: fmt.Sprintf("%v", child),
+			Actual:   fmt.Sprintf("%v", decision.Child),
+			Envelope: envelope,
+		}
 	}
 
-	return ""
+	return nil
 }
@@ -3,9 +3,12 @@ package catcher
 import (
 	"fmt"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/clock"
 	"github.com/meamidos/gopactor/options"
 )
 
@@ -20,41 +23,277 @@ type Envelope struct {
 // It seats in front of every tested actor and watches for
 // messages and system events.
 type Catcher struct {
-	// Channels for intercepted messages
-	ChSystemInbound chan *Envelope
-	ChUserInbound   chan *Envelope
-	ChUserOutbound  chan *Envelope
+	// Channels for intercepted messages.
+	// The system ones are buffered, and the envelopes which do not fit
+	// are queued by the catcher, so Next should be used to read them.
+	ChSystemInbound  chan *Envelope
+	ChSystemOutbound chan *Envelope
+	ChUserInbound    chan *Envelope
+	ChUserOutbound   chan *Envelope
 
 	// Channels for intercepted spawning of children
 	ChSpawning chan *actor.PID
 
+	// Channel for decisions made by the supervisor strategy.
+	// Every envelope carries a *Decision. It is buffered like the system channels.
+	ChSupervision chan *Envelope
+
+	// Used instead of the channels when journaling is enabled
+	Journal *Journal
+
+	// With recursive interception, catchers attached to children
+	// are registered here, so that children can be asserted on as well.
+	// It must be set before the actor is spawned.
+	Registry Registry
+
+	// Guards AssignedActor and Options. The middleware reads them
+	// from the actor's goroutine while a test may be spawning
+	// or asserting from another one.
+	mu sync.RWMutex
+
 	// One followed actor per catcher
 	AssignedActor *actor.PID
 
 	Options options.Options
+
+	// Every intercepted envelope in order, consumed or not.
+	// It is used to trace the interactions among actors.
+	historyMu sync.Mutex
+	history   []*Entry
+
+	// Envelopes put back by assertions to be consumed before any new ones
+	stashMu sync.Mutex
+	stash   map[Kind][]*Envelope
+
+	// System and supervision envelopes which do not fit into the channels' buffers
+	overflowMu sync.Mutex
+	overflow   map[Kind][]*Envelope
+
+	// Fault rules count matching messages by the index of the rule,
+	// and a reordering rule holds a message until the next one
+	faultsMu    sync.Mutex
+	faultCounts map[int]int
+	faultHeld   map[int]*heldMessage
+
+	// With gating, inbound messages are parked in order,
+	// and the test is woken up through the channel
+	gateMu sync.Mutex
+	parked []*ParkedMessage
+	chGate chan struct{}
+
+	// Closed by Close to unblock the middleware
+	done      chan struct{}
+	closeOnce sync.Once
+
+	// The number of middleware invocations in progress
+	busy int32
+
+	// The number of messages sent to a gated actor by other followed actors,
+	// which have not reached the actor yet
+	inflight int32
+
+	// The number of messages let through the gate, which have not been delivered yet
+	releasing int32
+
+	// Set when the actor fails, until it handles the next message
+	failed int32
+
+	// Goroutines which have run the actor, by their IDs
+	goroutinesMu sync.Mutex
+	goroutines   map[uint64]bool
+
+	// The number of messages the actor has started handling
+	handled uint32
+
+	// Markers sent by Sync, which have not passed the mailbox yet
+	syncMu  sync.Mutex
+	syncing map[*syncMarker]bool
+	stopped bool
+
+	// With a clock in the options, the receive timeout of the actor
+	// is scheduled on it rather than by Protoactor
+	timeoutMu      sync.Mutex
+	receiveTimeout time.Duration
+	timeoutTimer   clock.Timer
+	timeoutSelf    *actor.PID
+}
+
+// Registry keeps track of catchers and the actors they follow.
+type Registry interface {
+	Register(pid *actor.PID, catcher *Catcher)
+
+	// Lookup returns nil if the actor is not followed
+	Lookup(pid *actor.PID) *Catcher
 }
 
 // This is used for logging purposes only
 func (catcher *Catcher) id() string {
-	if catcher.AssignedActor != nil {
-		return catcher.AssignedActor.String()
+	if pid := catcher.getAssignedActor(); pid != nil {
+		return pid.String()
 	}
 
 	return "-"
 }
 
+// AssignedPID returns the PID of the followed actor.
+func (catcher *Catcher) AssignedPID() *actor.PID {
+	return catcher.getAssignedActor()
+}
+
+func (catcher *Catcher) getAssignedActor() *actor.PID {
+	catcher.mu.RLock()
+	defer catcher.mu.RUnlock()
+	return catcher.AssignedActor
+}
+
+func (catcher *Catcher) getOptions() options.Options {
+	catcher.mu.RLock()
+	defer catcher.mu.RUnlock()
+	return catcher.Options
+}
+
+// Next waits for the next intercepted envelope of a given kind.
+// Depending on the options, it is taken either from the journal or from the channels.
+func (catcher *Catcher) Next(kind Kind, timeout time.Duration) (*Envelope, bool) {
+	if envelope := catcher.unstash(kind); envelope != nil {
+		return envelope, true
+	}
+
+	if catcher.getOptions().JournalingEnabled {
+		entry, ok := catcher.Journal.Next(timeout, kind)
+		if !ok {
+			return nil, false
+		}
+		return entry.Envelope, true
+	}
+
+	var chEnvelopes chan *Envelope
+	switch kind {
+	case KindUserInbound:
+		chEnvelopes = catcher.ChUserInbound
+	case KindUserOutbound:
+		chEnvelopes = catcher.ChUserOutbound
+	case KindSystemInbound:
+		chEnvelopes = catcher.ChSystemInbound
+	case KindSystemOutbound:
+		chEnvelopes = catcher.ChSystemOutbound
+	case KindSupervision:
+		chEnvelopes = catcher.ChSupervision
+	case KindSpawning:
+		select {
+		case pid := <-catcher.ChSpawning:
+			return &Envelope{Sender: catcher.getAssignedActor(), Target: pid}, true
+		case <-time.After(timeout):
+			return nil, false
+		}
+	}
+
+	if cap(chEnvelopes) > 0 {
+		if envelope := catcher.unbuffer(kind, chEnvelopes); envelope != nil {
+			return envelope, true
+		}
+	}
+
+	select {
+	case envelope := <-chEnvelopes:
+		return envelope, true
+	case <-time.After(timeout):
+		return nil, false
+	}
+}
+
+// History returns all envelopes intercepted so far, in order,
+// no matter whether they have been consumed by assertions or not.
+func (catcher *Catcher) History() []*Entry {
+	catcher.historyMu.Lock()
+	defer catcher.historyMu.Unlock()
+
+	history := make([]*Entry, len(catcher.history))
+	copy(history, catcher.history)
+	return history
+}
+
+func (catcher *Catcher) record(kind Kind, envelope *Envelope) *Entry {
+	entry := newEntry(kind, envelope)
+
+	catcher.historyMu.Lock()
+	catcher.history = append(catcher.history, entry)
+	catcher.historyMu.Unlock()
+
+	return entry
+}
+
+// putBack returns envelopes to the catcher, so that they are consumed
+// again, in the same order, before any new envelopes of the same kind.
+func (catcher *Catcher) putBack(kind Kind, envelopes []*Envelope) {
+	if len(envelopes) == 0 {
+		return
+	}
+
+	catcher.stashMu.Lock()
+	defer catcher.stashMu.Unlock()
+
+	if catcher.stash == nil {
+		catcher.stash = make(map[Kind][]*Envelope)
+	}
+	catcher.stash[kind] = append(envelopes, catcher.stash[kind]...)
+}
+
+func (catcher *Catcher) unstash(kind Kind) *Envelope {
+	catcher.stashMu.Lock()
+	defer catcher.stashMu.Unlock()
+
+	envelopes := catcher.stash[kind]
+	if len(envelopes) == 0 {
+		return nil
+	}
+
+	catcher.stash[kind] = envelopes[1:]
+	return envelopes[0]
+}
+
 // New creates a new instance of Catcher.
 func New() *Catcher {
 	return &Catcher{
-		ChSystemInbound: make(chan *Envelope, 10),
+		ChSystemInbound:  make(chan *Envelope, 10),
+		ChSystemOutbound: make(chan *Envelope, 10),
+		ChSupervision:    make(chan *Envelope, 10),
 
 		// These are deliberately not buffered to make synchronization points
 		ChUserInbound:  make(chan *Envelope),
 		ChUserOutbound: make(chan *Envelope),
 		ChSpawning:     make(chan *actor.PID),
+
+		Journal: NewJournal(),
+
+		chGate: make(chan struct{}, 1),
+
+		done: make(chan struct{}),
 	}
 }
 
+// Close stops the interception. The middleware does not block
+// anymore, and intercepted envelopes are dropped from now on.
+// The followed actor keeps running.
+func (catcher *Catcher) Close() {
+	catcher.closeOnce.Do(func() {
+		close(catcher.done)
+		catcher.flushHeld("the catcher closed")
+		catcher.passAll()
+	})
+}
+
+// Busy tells whether the followed actor is handling a message right now.
+func (catcher *Catcher) Busy() bool {
+	return atomic.LoadInt32(&catcher.busy) > 0
+}
+
+// InFlight tells whether messages sent to a gated actor by other actors
+// followed by the same registry, or let through the gate, are still on the way.
+func (catcher *Catcher) InFlight() bool {
+	return atomic.LoadInt32(&catcher.inflight) > 0 || atomic.LoadInt32(&catcher.releasing) > 0
+}
+
 // Spawn an actor with injected middleware.
 func (catcher *Catcher) Spawn(props *actor.Props, opts ...options.Options) (*actor.PID, error) {
 	var opt options.Options
@@ -64,96 +303,346 @@ func (catcher *Catcher) Spawn(props *actor.Props, opts ...options.Options) (*act
 		opt = opts[0]
 	}
 
+	pid, err := actor.SpawnPrefix(catcher.prepare(props, opt), opt.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	catcher.assign(pid)
+
+	return pid, nil
+}
+
+// needsInboundMiddleware tells whether the actor is spawned with the inbound middleware
+func (catcher *Catcher) needsInboundMiddleware(opt options.Options) bool {
+	return opt.InboundInterceptionEnabled || opt.SystemInterceptionEnabled || opt.SpawnInterceptionEnabled ||
+		opt.DummySpawningEnabled || len(opt.Substitutions) > 0 || opt.RecursiveInterceptionDepth > 0 ||
+		opt.OutboundSystemInterceptionEnabled || len(opt.Faults) > 0 || opt.GatingEnabled || opt.Clock != nil || catcher.Registry != nil
+}
+
+// prepare sets the options and injects the middleware into the props.
+// Options must be in place before the actor is spawned,
+// because the middleware may start running right away.
+func (catcher *Catcher) prepare(props *actor.Props, opt options.Options) *actor.Props {
+	catcher.mu.Lock()
 	catcher.Options = opt
+	catcher.mu.Unlock()
 
-	if opt.InboundInterceptionEnabled || opt.SystemInterceptionEnabled || opt.SpawnInterceptionEnabled || opt.DummySpawningEnabled {
+	if catcher.needsInboundMiddleware(opt) {
 		props = props.WithMiddleware(catcher.inboundMiddleware)
 	}
 
-	if opt.OutboundInterceptionEnabled {
+	if opt.OutboundInterceptionEnabled || opt.OutboundSystemInterceptionEnabled || len(opt.Faults) > 0 {
 		props = props.WithOutboundMiddleware(catcher.outboundMiddleware)
 	}
 
-	pid, err := actor.SpawnPrefix(props, opt.Prefix)
-	if err != nil {
-		return nil, err
+	// A strategy given in the options is wrapped even if supervision is not recorded,
+	// so that followed children know whether they are resumed after a failure
+	if opt.SupervisionRecordingEnabled || opt.SupervisorStrategy != nil {
+		strategy := opt.SupervisorStrategy
+		if strategy == nil {
+			strategy = actor.DefaultSupervisorStrategy()
+		}
+		props = props.WithSupervisor(&recordingStrategy{catcher, strategy})
 	}
 
+	return props
+}
+
+func (catcher *Catcher) assign(pid *actor.PID) {
+	catcher.mu.Lock()
 	catcher.AssignedActor = pid
-	return pid, nil
+	catcher.mu.Unlock()
 }
 
-func (catcher *Catcher) ShouldReceive(sender *actor.PID, msg interface{}) string {
-	select {
-	case envelope := <-catcher.ChUserInbound:
-		if msg == nil { // Any massage will suffice
-			return ""
-		} else {
-			return assertInboundMessage(envelope, msg, sender)
-		}
-	case <-time.After(catcher.Options.Timeout):
-		return fmt.Sprintf("Timeout %s while waiting for a message", catcher.Options.Timeout)
+// Assertions return a *Failure as an error. A zero timeout means
+// the timeout from the options. The goconvey-style Should* methods
+// are thin adapters over them.
+
+func (catcher *Catcher) AssertReceive(sender *actor.PID, msg interface{}, timeout time.Duration) error {
+	envelope, err := catcher.Capture(KindUserInbound, timeout)
+	if err != nil {
+		return err
+	}
+
+	if msg == nil { // Any massage will suffice
+		return nil
 	}
+
+	return assertInboundMessage(envelope, msg, sender)
 }
 
-func (catcher *Catcher) ShouldReceiveSysMsg(msg interface{}) string {
+func (catcher *Catcher) AssertReceiveSysMsg(msg interface{}, timeout time.Duration) error {
+	timeout = catcher.timeout(timeout)
+
 	for {
-		select {
-		case envelope := <-catcher.ChSystemInbound:
-			if msg == nil { // Any message is ok
-				return ""
-			} else {
-				// Ignore unmatching messages
-				// This is important. Otherwise we would always have to check for
-				// for the Start message first. And potentially for other intermediate messages.
-				match := assertInboundMessage(envelope, msg, nil)
-				if match == "" {
-					return ""
-				}
-			}
-		case <-time.After(catcher.Options.Timeout):
-			return fmt.Sprintf("Timeout %s while waiting for a system message", catcher.Options.Timeout)
+		envelope, ok := catcher.Next(KindSystemInbound, timeout)
+		if !ok {
+			return timeoutFailure(timeout, "a system message")
+		}
+
+		if msg == nil { // Any message is ok
+			return nil
+		}
+
+		// Ignore unmatching messages
+		// This is important. Otherwise we would always have to check for
+		// for the Start message first. And potentially for other intermediate messages.
+		if assertInboundMessage(envelope, msg, nil) == nil {
+			return nil
 		}
 	}
 }
 
-func (catcher *Catcher) ShouldSend(receiver *actor.PID, msg interface{}) string {
-	select {
-	case envelope := <-catcher.ChUserOutbound:
-		if msg == nil { // Any message will suffice
-			return ""
-		} else {
-			return assertOutboundMessage(envelope, msg, receiver)
+// AssertSendSysMsg waits for a system message sent by the actor,
+// e.g. Stop, Watch, Unwatch or PoisonPill. A nil receiver means any receiver.
+// Other system messages sent in the meantime are skipped.
+func (catcher *Catcher) AssertSendSysMsg(receiver *actor.PID, msg interface{}, timeout time.Duration) error {
+	timeout = catcher.timeout(timeout)
+
+	for {
+		envelope, ok := catcher.Next(KindSystemOutbound, timeout)
+		if !ok {
+			return timeoutFailure(timeout, kindDescriptions[KindSystemOutbound])
+		}
+
+		if envelopeMatches(envelope, msg, nil, receiver) {
+			return nil
 		}
-	case <-time.After(catcher.Options.Timeout):
-		return fmt.Sprintf("Timeout %s while waiting for sending", catcher.Options.Timeout)
 	}
 }
 
-func (catcher *Catcher) ShouldNotSendOrReceive(pid *actor.PID) string {
+func (catcher *Catcher) AssertSend(receiver *actor.PID, msg interface{}, timeout time.Duration) error {
+	envelope, err := catcher.Capture(KindUserOutbound, timeout)
+	if err != nil {
+		return err
+	}
+
+	if msg == nil { // Any message will suffice
+		return nil
+	}
+
+	return assertOutboundMessage(envelope, msg, receiver)
+}
+
+func (catcher *Catcher) AssertNotSendOrReceive(timeout time.Duration) error {
+	timeout = catcher.timeout(timeout)
+
+	// Envelopes put back by previous assertions count as well
+	if envelope := catcher.unstash(KindUserOutbound); envelope != nil {
+		return forbiddenTraffic("outbound", envelope)
+	}
+	if envelope := catcher.unstash(KindUserInbound); envelope != nil {
+		return forbiddenTraffic("inbound", envelope)
+	}
+
+	if catcher.getOptions().JournalingEnabled {
+		entry, ok := catcher.Journal.Next(timeout, KindUserInbound, KindUserOutbound)
+		if !ok {
+			return nil
+		}
+
+		if entry.Kind == KindUserOutbound {
+			return forbiddenTraffic("outbound", entry.Envelope)
+		}
+		return forbiddenTraffic("inbound", entry.Envelope)
+	}
+
 	select {
 	case envelope := <-catcher.ChUserOutbound:
-		return fmt.Sprintf("Got outbound message: %#v", envelope.Message)
+		return forbiddenTraffic("outbound", envelope)
 	case envelope := <-catcher.ChUserInbound:
-		return fmt.Sprintf("Got inbound message: %#v", envelope.Message)
-	case <-time.After(catcher.Options.Timeout):
-		return ""
+		return forbiddenTraffic("inbound", envelope)
+	case <-time.After(timeout):
+		return nil
+	}
+}
+
+func (catcher *Catcher) AssertSpawn(match string, timeout time.Duration) error {
+	envelope, err := catcher.Capture(KindSpawning, timeout)
+	if err != nil {
+		return err
+	}
+
+	if match == "" { // Any spawned actor will suffice
+		return nil
+	}
+
+	return assertSpawnedActor(envelope, match)
+}
+
+func (catcher *Catcher) AssertNotReceive(sender *actor.PID, msg interface{}, timeout time.Duration) error {
+	envelope := catcher.shouldNotGet(KindUserInbound, timeout, func(envelope *Envelope) bool {
+		return envelopeMatches(envelope, msg, sender, nil)
+	})
+
+	if envelope != nil {
+		return &Failure{
+			Reason: fmt.Sprintf(`
+Received a forbidden message
+Message: %#v
+Sender: %v
+`, envelope.Message, envelope.Sender),
+			Envelope: envelope,
+		}
+	}
+
+	return nil
+}
+
+func (catcher *Catcher) AssertNotReceiveSysMsg(msg interface{}, timeout time.Duration) error {
+	envelope := catcher.shouldNotGet(KindSystemInbound, timeout, func(envelope *Envelope) bool {
+		return envelopeMatches(envelope, msg, nil, nil)
+	})
+
+	if envelope != nil {
+		return &Failure{
+			Reason:   fmt.Sprintf("Received a forbidden system message: %#v", envelope.Message),
+			Envelope: envelope,
+		}
+	}
+
+	return nil
+}
+
+func (catcher *Catcher) AssertNotSend(receiver *actor.PID, msg interface{}, timeout time.Duration) error {
+	envelope := catcher.shouldNotGet(KindUserOutbound, timeout, func(envelope *Envelope) bool {
+		return envelopeMatches(envelope, msg, nil, receiver)
+	})
+
+	if envelope != nil {
+		return &Failure{
+			Reason: fmt.Sprintf(`
+Sent a forbidden message
+Message: %#v
+Receiver: %v
+`, envelope.Message, envelope.Target),
+			Envelope: envelope,
+		}
+	}
+
+	return nil
+}
+
+func (catcher *Catcher) AssertNotSpawn(match string, timeout time.Duration) error {
+	envelope := catcher.shouldNotGet(KindSpawning, timeout, func(envelope *Envelope) bool {
+		return strings.Contains(envelope.Target.String(), match)
+	})
+
+	if envelope != nil {
+		return &Failure{
+			Reason:   fmt.Sprintf("Spawned a forbidden child: %s", envelope.Target),
+			Envelope: envelope,
+		}
 	}
+
+	return nil
+}
+
+func (catcher *Catcher) AssertDecide(child *actor.PID, directive actor.Directive, timeout time.Duration) error {
+	envelope, err := catcher.Capture(KindSupervision, timeout)
+	if err != nil {
+		return err
+	}
+
+	return assertDecision(envelope, child, directive)
+}
+
+// Capture waits for the next envelope of a given kind and returns it as is.
+func (catcher *Catcher) Capture(kind Kind, timeout time.Duration) (*Envelope, error) {
+	timeout = catcher.timeout(timeout)
+
+	envelope, ok := catcher.Next(kind, timeout)
+	if !ok {
+		return nil, timeoutFailure(timeout, kindDescriptions[kind])
+	}
+
+	return envelope, nil
+}
+
+func (catcher *Catcher) ShouldReceive(sender *actor.PID, msg interface{}) string {
+	return FailureMessage(catcher.AssertReceive(sender, msg, 0))
+}
+
+func (catcher *Catcher) ShouldReceiveSysMsg(msg interface{}) string {
+	return FailureMessage(catcher.AssertReceiveSysMsg(msg, 0))
+}
+
+func (catcher *Catcher) ShouldSend(receiver *actor.PID, msg interface{}) string {
+	return FailureMessage(catcher.AssertSend(receiver, msg, 0))
+}
+
+func (catcher *Catcher) ShouldNotSendOrReceive(pid *actor.PID) string {
+	return FailureMessage(catcher.AssertNotSendOrReceive(0))
 }
 
 func (catcher *Catcher) ShouldSpawn(match string) string {
-	select {
-	case pid := <-catcher.ChSpawning:
-		if match == "" { // Any spawned actor will suffice
-			return ""
-		} else {
-			if strings.Contains(pid.String(), match) {
-				return ""
-			} else {
-				return assertSpawnedActor(pid, match)
-			}
-		}
-	case <-time.After(catcher.Options.Timeout):
-		return fmt.Sprintf("Timeout %s while waiting for spawning", catcher.Options.Timeout)
+	return FailureMessage(catcher.AssertSpawn(match, 0))
+}
+
+func (catcher *Catcher) ShouldNotReceive(sender *actor.PID, msg interface{}) string {
+	return FailureMessage(catcher.AssertNotReceive(sender, msg, 0))
+}
+
+func (catcher *Catcher) ShouldNotReceiveSysMsg(msg interface{}) string {
+	return FailureMessage(catcher.AssertNotReceiveSysMsg(msg, 0))
+}
+
+func (catcher *Catcher) ShouldNotSend(receiver *actor.PID, msg interface{}) string {
+	return FailureMessage(catcher.AssertNotSend(receiver, msg, 0))
+}
+
+func (catcher *Catcher) ShouldNotSpawn(match string) string {
+	return FailureMessage(catcher.AssertNotSpawn(match, 0))
+}
+
+func (catcher *Catcher) ShouldDecide(child *actor.PID, directive actor.Directive) string {
+	return FailureMessage(catcher.AssertDecide(child, directive, 0))
+}
+
+var kindDescriptions = map[Kind]string{
+	KindUserInbound:    "a message",
+	KindUserOutbound:   "sending",
+	KindSystemInbound:  "a system message",
+	KindSystemOutbound: "sending a system message",
+	KindSpawning:       "spawning",
+	KindSupervision:    "a supervisor decision",
+}
+
+func (catcher *Catcher) timeout(timeout time.Duration) time.Duration {
+	if timeout > 0 {
+		return timeout
+	}
+
+	return catcher.getOptions().Timeout
+}
+
+func forbiddenTraffic(direction string, envelope *Envelope) *Failure {
+	return &Failure{
+		Reason:   fmt.Sprintf("Got %s message: %#v", direction, envelope.Message),
+		Envelope: envelope,
+	}
+}
+
+// shouldNotGet consumes envelopes of a given kind until the timeout expires.
+// Envelopes which are not forbidden are simply dropped.
+// The first forbidden envelope is returned right away.
+func (catcher *Catcher) shouldNotGet(kind Kind, timeout time.Duration, forbidden func(*Envelope) bool) *Envelope {
+	deadline := time.Now().Add(catcher.timeout(timeout))
+
+	for {
+		timeout := deadline.Sub(time.Now())
+		if timeout <= 0 {
+			return nil
+		}
+
+		envelope, ok := catcher.Next(kind, timeout)
+		if !ok {
+			return nil
+		}
+
+		if forbidden(envelope) {
+			return envelope
+		}
 	}
 }
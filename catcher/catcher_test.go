@@ -168,3 +168,97 @@ func TestCatcher_ContextSpawnInterception(t *testing.T) {
 		})
 	})
 }
+
+func TestCatcher_Journaling(t *testing.T) {
+	Convey("Subject: Journaling of intercepted envelopes", t, func() {
+		Convey("Given a catcher and a parent that spawns a child on request", func() {
+			catch := catcher.New()
+
+			childProps := actor.FromFunc(func(ctx actor.Context) {})
+			parentProps := actor.FromFunc(func(ctx actor.Context) {
+				switch m := ctx.Message().(type) {
+				case string:
+					if m == "spawn" && ctx.Sender() != nil {
+						child := ctx.SpawnPrefix(childProps, "journaled-child")
+						ctx.Respond(child)
+					}
+				}
+			})
+
+			Convey("And the parent is intercepted in the journaling mode", func() {
+				parent, err := catch.Spawn(parentProps, options.OptDefault.WithSpawnInterception().WithJournaling())
+				So(err, ShouldBeNil)
+
+				Convey("When sending a request to spawn a child", func() {
+					res, err := parent.RequestFuture("spawn", options.DEFAULT_TIMEOUT).Result()
+
+					// Nobody has asserted anything yet, but the parent is not blocked
+					Convey("Then get a response right away", func() {
+						So(err, ShouldBeNil)
+						_, ok := res.(*actor.PID)
+						So(ok, ShouldBeTrue)
+					})
+
+					Convey("Then the whole conversation is recorded in order", func() {
+						So(catch.ShouldReceive(nil, "spawn"), ShouldBeEmpty)
+						So(catch.ShouldSpawn("journaled-child"), ShouldBeEmpty)
+						So(catch.ShouldSend(nil, res), ShouldBeEmpty)
+						So(catch.Journal.Len(), ShouldEqual, 0)
+					})
+				})
+
+				Convey("When sending many messages", func() {
+					for i := 0; i < 100; i++ {
+						parent.Tell(i)
+					}
+
+					Convey("Then they are consumed in the order of arrival", func() {
+						for i := 0; i < 100; i++ {
+							So(catch.ShouldReceive(nil, i), ShouldBeEmpty)
+						}
+						So(catch.ShouldReceive(nil, nil), ShouldContainSubstring, "Timeout")
+					})
+				})
+			})
+		})
+	})
+}
+
+func TestCatcher_SystemOverflow(t *testing.T) {
+	Convey("Subject: System events beyond the channel's buffer", t, func() {
+		Convey("Given an actor with only system messages intercepted", func() {
+			catch := catcher.New()
+
+			props := actor.FromFunc(func(ctx actor.Context) {
+				switch m := ctx.Message().(type) {
+				case string:
+					if m == "ping" && ctx.Sender() != nil {
+						ctx.Respond("pong")
+					}
+				}
+			})
+
+			pid, err := catch.Spawn(props, options.OptNoInterception.WithSystemInterception())
+			So(err, ShouldBeNil)
+
+			Convey("When it gets many system messages nobody asserts on", func() {
+				for i := 0; i < 50; i++ {
+					pid.Tell(&actor.ReceiveTimeout{})
+				}
+
+				// The actor is not blocked by the unread events
+				res, err := pid.RequestFuture("ping", options.DEFAULT_TIMEOUT).Result()
+				So(err, ShouldBeNil)
+				So(res, ShouldEqual, "pong")
+
+				Convey("Then none of them is lost, and they are consumed in order", func() {
+					So(catch.ShouldReceiveSysMsg(&actor.Started{}), ShouldBeEmpty)
+					for i := 0; i < 50; i++ {
+						So(catch.ShouldReceiveSysMsg(&actor.ReceiveTimeout{}), ShouldBeEmpty)
+					}
+					So(catch.ShouldReceiveSysMsg(nil), ShouldContainSubstring, "Timeout")
+				})
+			})
+		})
+	})
+}
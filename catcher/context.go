@@ -1,7 +1,12 @@
 package catcher
 
 import (
+	"path"
+	"reflect"
+	"time"
+
 	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/options"
 )
 
 type NullReceiver struct{}
@@ -14,21 +19,105 @@ func (nr *NullReceiver) Receive(ctx actor.Context) {}
 type Context struct {
 	catcher       *Catcher
 	actor.Context // This is the original context to pass calls to
+
+	// A message delivered in place of the current one of the original context,
+	// e.g. when it has been delayed or reordered by a fault rule
+	envelope *Envelope
 }
 
 func NewContext(catcher *Catcher, ctx actor.Context) *Context {
-	return &Context{catcher, ctx}
+	return &Context{catcher: catcher, Context: ctx}
+}
+
+// withEnvelope returns a copy of the context which delivers a given message
+func (ctx *Context) withEnvelope(envelope *Envelope) *Context {
+	return &Context{catcher: ctx.catcher, Context: ctx.Context, envelope: envelope}
+}
+
+func (ctx *Context) Message() interface{} {
+	if ctx.envelope != nil {
+		return ctx.envelope.Message
+	}
+
+	return ctx.Context.Message()
+}
+
+func (ctx *Context) Sender() *actor.PID {
+	if ctx.envelope != nil {
+		return ctx.envelope.Sender
+	}
+
+	return ctx.Context.Sender()
+}
+
+func (ctx *Context) Respond(response interface{}) {
+	if ctx.envelope != nil {
+		ctx.Tell(ctx.Sender(), response)
+		return
+	}
+
+	ctx.Context.Respond(response)
+}
+
+// With a clock in the options, the receive timeout is scheduled on it
+// instead of the real time
+func (ctx *Context) SetReceiveTimeout(d time.Duration) {
+	if ctx.catcher.getOptions().Clock == nil {
+		ctx.Context.SetReceiveTimeout(d)
+		return
+	}
+
+	ctx.catcher.setReceiveTimeout(ctx.Self(), d)
+}
+
+func (ctx *Context) ReceiveTimeout() time.Duration {
+	if ctx.catcher.getOptions().Clock == nil {
+		return ctx.Context.ReceiveTimeout()
+	}
+
+	return ctx.catcher.getReceiveTimeout()
+}
+
+// Watch and Unwatch send system messages directly to the mailbox
+// of the other actor, bypassing the outbound middleware.
+// Protoactor handles them before the inbound middleware as well.
+// So, they are handed over to the catcher of the other actor directly,
+// if it is followed by the same registry.
+func (ctx *Context) Watch(pid *actor.PID) {
+	ctx.interceptWatching(pid, &actor.Watch{Watcher: ctx.Self()})
+	ctx.Context.Watch(pid)
+}
+
+func (ctx *Context) Unwatch(pid *actor.PID) {
+	ctx.interceptWatching(pid, &actor.Unwatch{Watcher: ctx.Self()})
+	ctx.Context.Unwatch(pid)
+}
+
+func (ctx *Context) interceptWatching(pid *actor.PID, msg interface{}) {
+	envelope := &Envelope{Sender: ctx.Self(), Target: pid, Message: msg}
+
+	if ctx.catcher.getOptions().OutboundSystemInterceptionEnabled {
+		ctx.catcher.intercept(KindSystemOutbound, envelope)
+	}
+
+	if ctx.catcher.Registry == nil {
+		return
+	}
+
+	if other := ctx.catcher.Registry.Lookup(pid); other != nil && other.getOptions().SystemInterceptionEnabled {
+		other.intercept(KindSystemInbound, envelope)
+	}
 }
 
 func (ctx *Context) Spawn(props *actor.Props) *actor.PID {
 	catcher := ctx.catcher
-	if catcher.Options.DummySpawningEnabled {
-		props = actor.FromInstance(&NullReceiver{})
-	}
+	opt := catcher.getOptions()
+	props, child := ctx.childProps(opt, props, "")
 
 	pid := ctx.Context.Spawn(props)
-	if catcher.Options.SpawnInterceptionEnabled {
-		catcher.ChSpawning <- pid
+	ctx.adopt(pid, child)
+	if opt.SpawnInterceptionEnabled {
+		catcher.intercept(KindSpawning, &Envelope{Sender: ctx.Self(), Target: pid})
 	}
 
 	return pid
@@ -36,13 +125,13 @@ func (ctx *Context) Spawn(props *actor.Props) *actor.PID {
 
 func (ctx *Context) SpawnPrefix(props *actor.Props, prefix string) *actor.PID {
 	catcher := ctx.catcher
-	if catcher.Options.DummySpawningEnabled {
-		props = actor.FromInstance(&NullReceiver{})
-	}
+	opt := catcher.getOptions()
+	props, child := ctx.childProps(opt, props, prefix)
 
 	pid := ctx.Context.SpawnPrefix(props, prefix)
-	if catcher.Options.SpawnInterceptionEnabled {
-		catcher.ChSpawning <- pid
+	ctx.adopt(pid, child)
+	if opt.SpawnInterceptionEnabled {
+		catcher.intercept(KindSpawning, &Envelope{Sender: ctx.Self(), Target: pid})
 	}
 
 	return pid
@@ -50,14 +139,93 @@ func (ctx *Context) SpawnPrefix(props *actor.Props, prefix string) *actor.PID {
 
 func (ctx *Context) SpawnNamed(props *actor.Props, id string) (*actor.PID, error) {
 	catcher := ctx.catcher
-	if catcher.Options.DummySpawningEnabled {
-		props = actor.FromInstance(&NullReceiver{})
-	}
+	opt := catcher.getOptions()
+	props, child := ctx.childProps(opt, props, id)
 
 	pid, err := ctx.Context.SpawnNamed(props, id)
-	if err == nil && catcher.Options.SpawnInterceptionEnabled {
-		catcher.ChSpawning <- pid
+	if err != nil {
+		return pid, err
+	}
+
+	ctx.adopt(pid, child)
+	if opt.SpawnInterceptionEnabled {
+		catcher.intercept(KindSpawning, &Envelope{Sender: ctx.Self(), Target: pid})
+	}
+
+	return pid, nil
+}
+
+// childProps decides what is actually spawned instead of the requested child.
+// With recursive interception, a new catcher is attached to the child.
+func (ctx *Context) childProps(opt options.Options, props *actor.Props, name string) (*actor.Props, *Catcher) {
+	props = substitute(opt, props, name)
+
+	if opt.RecursiveInterceptionDepth <= 0 || ctx.catcher.Registry == nil {
+		return props, nil
+	}
+
+	childOpt := opt
+	childOpt.RecursiveInterceptionDepth--
+
+	child := New()
+	child.Registry = ctx.catcher.Registry
+	return child.prepare(props, childOpt), child
+}
+
+// adopt registers the catcher attached to a spawned child, if any.
+func (ctx *Context) adopt(pid *actor.PID, child *Catcher) {
+	if child == nil {
+		return
+	}
+
+	child.assign(pid)
+	child.Registry.Register(pid, child)
+}
+
+func substitute(opt options.Options, props *actor.Props, name string) *actor.Props {
+	byType := false
+	for _, substitution := range opt.Substitutions {
+		if substitution.ActorType != nil {
+			byType = true
+			continue
+		}
+
+		if name == "" {
+			continue
+		}
+
+		if matched, _ := path.Match(substitution.Pattern, name); matched {
+			return actor.FromInstance(substitution.Stub)
+		}
 	}
 
-	return pid, err
+	// The type of the actor is only known after the props produce it,
+	// so the child is spawned as usual and the stub takes over its messages.
+	if byType {
+		return props.WithMiddleware(substitutionMiddleware(opt.Substitutions, opt.DummySpawningEnabled))
+	}
+
+	if opt.DummySpawningEnabled {
+		return actor.FromInstance(&NullReceiver{})
+	}
+
+	return props
+}
+
+func substitutionMiddleware(substitutions []options.Substitution, dummy bool) actor.InboundMiddleware {
+	return func(next actor.ActorFunc) actor.ActorFunc {
+		return func(ctx actor.Context) {
+			actorType := reflect.TypeOf(ctx.Actor())
+			for _, substitution := range substitutions {
+				if substitution.ActorType == actorType {
+					substitution.Stub.Receive(ctx)
+					return
+				}
+			}
+
+			if !dummy {
+				next(ctx)
+			}
+		}
+	}
 }
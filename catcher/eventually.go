@@ -0,0 +1,64 @@
+package catcher
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+// AssertEventually waits until an envelope of a given kind matches
+// the expected message and the peer: the sender of an inbound message
+// or the target of an outbound one. Nil values match anything.
+// Envelopes which do not match are skipped. They are discarded,
+// unless keep is set: then they are put back once the assertion is over,
+// so that the following assertions see them in the original order.
+func (catcher *Catcher) AssertEventually(kind Kind, peer *actor.PID, msg interface{}, keep bool, timeout time.Duration) error {
+	timeout = catcher.timeout(timeout)
+	deadline := time.Now().Add(timeout)
+
+	// An envelope spec (see MsgFrom and MsgTo) brings its own peers
+	expected := &Envelope{Message: msg}
+	if spec, ok := msg.(*Envelope); ok {
+		expected = spec
+	}
+
+	if kind == KindUserOutbound && peer != nil {
+		expected = &Envelope{Sender: expected.Sender, Target: peer, Message: expected.Message}
+	} else if peer != nil {
+		expected = &Envelope{Sender: peer, Target: expected.Target, Message: expected.Message}
+	}
+
+	skipped := []*Envelope{}
+	defer func() {
+		if keep {
+			catcher.putBack(kind, skipped)
+		}
+	}()
+
+	for {
+		left := deadline.Sub(time.Now())
+		if left <= 0 {
+			break
+		}
+
+		envelope, ok := catcher.Next(kind, left)
+		if !ok {
+			break
+		}
+
+		if envelopeMatches(envelope, expected.Message, expected.Sender, expected.Target) {
+			return nil
+		}
+
+		skipped = append(skipped, envelope)
+	}
+
+	return &Failure{
+		Reason:     fmt.Sprintf("Timeout %s while waiting for a matching message", timeout),
+		Timeout:    timeout,
+		Met:        []interface{}{},
+		Missing:    []interface{}{expected},
+		Unexpected: skipped,
+	}
+}
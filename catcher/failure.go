@@ -0,0 +1,101 @@
+package catcher
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/meamidos/gopactor/matchers"
+)
+
+// Failure is a structured description of a failed assertion.
+// It is returned as an error by all Assert* methods of the catcher.
+type Failure struct {
+	// What went wrong, e.g. "Messages do not match"
+	Reason string
+
+	// Human-readable descriptions of the expected and the actual values.
+	// Both are empty when there is nothing to compare, e.g. on timeouts.
+	Expected string
+	Actual   string
+
+	// The intercepted envelope the assertion failed on, if any
+	Envelope *Envelope
+
+	// Non-zero if the assertion failed because nothing happened in time
+	Timeout time.Duration
+
+	// Assertions about sets of messages report every expectation
+	// and every collected envelope.
+	Met        []interface{}
+	Missing    []interface{}
+	Unexpected []*Envelope
+}
+
+func (f *Failure) Error() string {
+	if f.Met != nil || f.Missing != nil || f.Unexpected != nil {
+		return fmt.Sprintf(`
+%s
+Met: %s
+Missing: %s
+Unexpected: %s
+`, f.Reason, describeAll(f.Met), describeAll(f.Missing), describeEnvelopes(f.Unexpected))
+	}
+
+	if f.Expected == "" && f.Actual == "" {
+		return f.Reason
+	}
+
+	return fmt.Sprintf(`
+%s
+Expected: %s
+Actual: %s
+`, f.Reason, f.Expected, f.Actual)
+}
+
+// FailureMessage converts an error returned by an assertion
+// into a goconvey-style result: an empty string means success.
+func FailureMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
+
+func timeoutFailure(timeout time.Duration, what string) *Failure {
+	return &Failure{
+		Reason:  fmt.Sprintf("Timeout %s while waiting for %s", timeout, what),
+		Timeout: timeout,
+	}
+}
+
+func describeAll(expected []interface{}) string {
+	if len(expected) == 0 {
+		return "none"
+	}
+
+	descriptions := make([]string, 0, len(expected))
+	for _, e := range expected {
+		if spec, ok := e.(*Envelope); ok {
+			descriptions = append(descriptions, spec.describe())
+			continue
+		}
+		descriptions = append(descriptions, matchers.Describe(e))
+	}
+
+	return strings.Join(descriptions, ", ")
+}
+
+func describeEnvelopes(envelopes []*Envelope) string {
+	if len(envelopes) == 0 {
+		return "none"
+	}
+
+	descriptions := make([]string, 0, len(envelopes))
+	for _, envelope := range envelopes {
+		descriptions = append(descriptions, fmt.Sprintf("%#v (%v -> %v)", envelope.Message, envelope.Sender, envelope.Target))
+	}
+
+	return strings.Join(descriptions, ", ")
+}
@@ -0,0 +1,146 @@
+package catcher
+
+import (
+	"log"
+	"sort"
+	"time"
+
+	"github.com/meamidos/gopactor/options"
+)
+
+// delayedMessage carries an inbound message delayed by a fault rule
+// back to the mailbox of the actor. It is delivered without faults.
+type delayedMessage struct {
+	envelope *Envelope
+}
+
+// heldMessage is a message held by a reordering rule until the next matching one
+type heldMessage struct {
+	fault        options.Fault
+	envelope     *Envelope
+	deliver      func()
+	deliverLater func()
+}
+
+// applyFault finds the first fault rule which applies to a user message and applies it.
+// The message is handed over with deliver, now or never, from the goroutine of the actor.
+// When it is delayed, or released after the actor has stopped, it is handed over
+// with deliverLater from another goroutine.
+func (catcher *Catcher) applyFault(outbound bool, envelope *Envelope, deliver func(), deliverLater func()) {
+	faults := catcher.getOptions().Faults
+
+	for i, fault := range faults {
+		if fault.Outbound != outbound || !fault.Filter.Matches(envelope.Sender, envelope.Target, envelope.Message) {
+			continue
+		}
+
+		// A held message is released by the next matching one, whatever the count
+		if fault.Action == options.FaultReorder {
+			if held := catcher.takeHeld(i); held != nil {
+				catcher.logFault(fault, held.envelope, "released after the next one")
+				deliver()
+				held.deliver()
+				return
+			}
+		}
+
+		// The message is left to the next rules
+		if !fault.Applies(catcher.countFault(i)) {
+			continue
+		}
+
+		switch fault.Action {
+		case options.FaultDrop:
+			catcher.logFault(fault, envelope, "dropped")
+
+		case options.FaultDelay:
+			catcher.logFault(fault, envelope, "delayed by "+fault.Delay.String())
+			time.AfterFunc(fault.Delay, deliverLater)
+
+		case options.FaultDuplicate:
+			catcher.logFault(fault, envelope, "delivered twice")
+			deliver()
+			deliver()
+
+		case options.FaultReorder:
+			catcher.logFault(fault, envelope, "held until the next one")
+			catcher.hold(i, &heldMessage{fault, envelope, deliver, deliverLater})
+
+		default:
+			deliver()
+		}
+
+		return
+	}
+
+	deliver()
+}
+
+// countFault returns the number of messages matched by the rule so far, this one included
+func (catcher *Catcher) countFault(rule int) int {
+	catcher.faultsMu.Lock()
+	defer catcher.faultsMu.Unlock()
+
+	if catcher.faultCounts == nil {
+		catcher.faultCounts = map[int]int{}
+	}
+
+	catcher.faultCounts[rule]++
+	return catcher.faultCounts[rule]
+}
+
+func (catcher *Catcher) hold(rule int, held *heldMessage) {
+	catcher.faultsMu.Lock()
+	defer catcher.faultsMu.Unlock()
+
+	if catcher.faultHeld == nil {
+		catcher.faultHeld = map[int]*heldMessage{}
+	}
+
+	catcher.faultHeld[rule] = held
+}
+
+func (catcher *Catcher) takeHeld(rule int) *heldMessage {
+	catcher.faultsMu.Lock()
+	defer catcher.faultsMu.Unlock()
+
+	held := catcher.faultHeld[rule]
+	delete(catcher.faultHeld, rule)
+	return held
+}
+
+// flushHeld hands over the messages which are still held when the actor stops
+// or the catcher is closed, since no next message would release them.
+// Messages sent by the actor are released, messages to the actor are dropped.
+func (catcher *Catcher) flushHeld(event string) {
+	catcher.faultsMu.Lock()
+	held := catcher.faultHeld
+	catcher.faultHeld = nil
+	catcher.faultsMu.Unlock()
+
+	rules := make([]int, 0, len(held))
+	for rule := range held {
+		rules = append(rules, rule)
+	}
+	sort.Ints(rules)
+
+	for _, rule := range rules {
+		message := held[rule]
+		if message.fault.Outbound {
+			catcher.logFault(message.fault, message.envelope, "released when "+event)
+			message.deliverLater()
+		} else {
+			catcher.logFault(message.fault, message.envelope, "dropped when "+event)
+		}
+	}
+}
+
+func (catcher *Catcher) logFault(fault options.Fault, envelope *Envelope, what string) {
+	direction := "inbound"
+	if fault.Outbound {
+		direction = "outbound"
+	}
+
+	log.Printf("Gopactor: %s: %s message %#v from %v to %v %s",
+		catcher.id(), direction, envelope.Message, envelope.Sender, envelope.Target, what)
+}
@@ -0,0 +1,159 @@
+package catcher
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+// ParkedMessage is an inbound message waiting at the gate
+// until the test decides what to deliver instead.
+// Only the first decision counts.
+type ParkedMessage struct {
+	Envelope *Envelope
+
+	catcher *Catcher
+	decided int32
+}
+
+// releasedMessage carries a message let through the gate
+// back to the mailbox of the actor. It is delivered without gating.
+type releasedMessage struct {
+	envelope *Envelope
+}
+
+// Release lets the message through
+func (parked *ParkedMessage) Release() {
+	parked.decide(parked.Envelope)
+}
+
+// Drop discards the message, it is never delivered
+func (parked *ParkedMessage) Drop() {
+	parked.decide(nil)
+}
+
+// Replace delivers another message instead, from the same sender
+func (parked *ParkedMessage) Replace(msg interface{}) {
+	parked.decide(&Envelope{Sender: parked.Envelope.Sender, Target: parked.Envelope.Target, Message: msg})
+}
+
+// decide removes the message from the queue and sends the envelope
+// back to the actor, unless it is nil
+func (parked *ParkedMessage) decide(envelope *Envelope) {
+	if !atomic.CompareAndSwapInt32(&parked.decided, 0, 1) {
+		return
+	}
+
+	catcher := parked.catcher
+	catcher.unpark(parked)
+
+	if envelope != nil {
+		atomic.AddInt32(&catcher.releasing, 1)
+		envelope.Target.Tell(&releasedMessage{envelope})
+	}
+}
+
+// park puts the message into the queue of the gate instead of delivering it.
+// The actor goes on with other messages, which are parked as well.
+// It returns false once the catcher is closed: the gate is open then.
+func (catcher *Catcher) park(envelope *Envelope) bool {
+	select {
+	case <-catcher.done:
+		return false
+	default:
+	}
+
+	catcher.gateMu.Lock()
+	catcher.parked = append(catcher.parked, &ParkedMessage{Envelope: envelope, catcher: catcher})
+	catcher.gateMu.Unlock()
+
+	// Wake up a test waiting for a parked message
+	select {
+	case catcher.chGate <- struct{}{}:
+	default:
+	}
+
+	return true
+}
+
+func (catcher *Catcher) unpark(parked *ParkedMessage) {
+	catcher.gateMu.Lock()
+	defer catcher.gateMu.Unlock()
+
+	for i, other := range catcher.parked {
+		if other == parked {
+			catcher.parked = append(catcher.parked[:i:i], catcher.parked[i+1:]...)
+			return
+		}
+	}
+}
+
+// Park waits for the actor to park a message, and takes over the oldest one.
+// It stays out of the queue until the test decides on it.
+// A zero timeout means the timeout from the options.
+func (catcher *Catcher) Park(timeout time.Duration) (*ParkedMessage, error) {
+	timeout = catcher.timeout(timeout)
+	expired := time.After(timeout)
+
+	for {
+		if parked := catcher.Parked(); parked != nil {
+			return parked, nil
+		}
+
+		select {
+		case <-catcher.chGate:
+		case <-expired:
+			return nil, &Failure{Reason: fmt.Sprintf("Timeout %s while waiting for a parked message", timeout)}
+		}
+	}
+}
+
+// Parked takes over the oldest message parked by the actor right now, if any.
+// It does not wait.
+func (catcher *Catcher) Parked() *ParkedMessage {
+	catcher.gateMu.Lock()
+	defer catcher.gateMu.Unlock()
+
+	if len(catcher.parked) == 0 {
+		return nil
+	}
+
+	parked := catcher.parked[0]
+	catcher.parked = catcher.parked[1:]
+	return parked
+}
+
+// ParkedMessages returns the messages parked by the actor right now, oldest first.
+// They stay in the queue until the test decides on them.
+func (catcher *Catcher) ParkedMessages() []*ParkedMessage {
+	catcher.gateMu.Lock()
+	defer catcher.gateMu.Unlock()
+
+	parked := make([]*ParkedMessage, len(catcher.parked))
+	copy(parked, catcher.parked)
+	return parked
+}
+
+// announce tells the catcher of a gated target, if any, that a message is on the way
+func (catcher *Catcher) announce(target *actor.PID) {
+	if catcher.Registry == nil || target == nil {
+		return
+	}
+
+	if other := catcher.Registry.Lookup(target); other != nil && other.getOptions().GatingEnabled {
+		atomic.AddInt32(&other.inflight, 1)
+	}
+}
+
+// arrive counts off a message announced by another catcher.
+// Messages from other senders are not announced, so the count never goes below zero.
+func (catcher *Catcher) arrive() {
+	for {
+		inflight := atomic.LoadInt32(&catcher.inflight)
+		if inflight <= 0 || atomic.CompareAndSwapInt32(&catcher.inflight, inflight, inflight-1) {
+			return
+		}
+	}
+}
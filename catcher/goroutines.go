@@ -0,0 +1,54 @@
+package catcher
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+)
+
+// recordGoroutine remembers the goroutine running the actor,
+// so that goroutines started by the actor can be traced back to it
+func (catcher *Catcher) recordGoroutine() {
+	id := goroutineID()
+	if id == 0 {
+		return
+	}
+
+	catcher.goroutinesMu.Lock()
+	defer catcher.goroutinesMu.Unlock()
+
+	if catcher.goroutines == nil {
+		catcher.goroutines = make(map[uint64]bool)
+	}
+	catcher.goroutines[id] = true
+}
+
+// Goroutines returns the IDs of the goroutines which have run the actor so far.
+func (catcher *Catcher) Goroutines() []uint64 {
+	catcher.goroutinesMu.Lock()
+	defer catcher.goroutinesMu.Unlock()
+
+	ids := make([]uint64, 0, len(catcher.goroutines))
+	for id := range catcher.goroutines {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// goroutineID parses the ID of the current goroutine
+// from the header of its stack: "goroutine 42 [running]:"
+func goroutineID() uint64 {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+
+	fields := bytes.Fields(buf[:n])
+	if len(fields) < 2 {
+		return 0
+	}
+
+	id, err := strconv.ParseUint(string(fields[1]), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
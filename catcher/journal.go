@@ -0,0 +1,114 @@
+package catcher
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Kind tells what sort of event a journal entry is about.
+type Kind int
+
+const (
+	KindUserInbound Kind = iota
+	KindUserOutbound
+	KindSystemInbound
+	KindSpawning
+	KindSupervision
+	KindSystemOutbound
+)
+
+// Sequence numbers are shared by all journals,
+// so that entries of different catchers can be put in order.
+var journalSeq uint64
+
+// Entry is a single record in a journal.
+type Entry struct {
+	Seq      uint64
+	Kind     Kind
+	Envelope *Envelope
+}
+
+// Journal is an unbounded ordered log of intercepted envelopes.
+// Appending never blocks, so an actor followed by a journaling catcher
+// keeps running no matter whether the test consumes the entries or not.
+type Journal struct {
+	mu      sync.Mutex
+	pending []*Entry
+
+	// Closed and replaced on every append to wake up the waiting consumers
+	signal chan struct{}
+}
+
+// NewJournal creates a new empty journal.
+func NewJournal() *Journal {
+	return &Journal{
+		signal: make(chan struct{}),
+	}
+}
+
+// Append adds a new entry to the end of the journal.
+func (j *Journal) Append(kind Kind, envelope *Envelope) *Entry {
+	entry := newEntry(kind, envelope)
+	j.appendEntry(entry)
+	return entry
+}
+
+func newEntry(kind Kind, envelope *Envelope) *Entry {
+	return &Entry{
+		Seq:      atomic.AddUint64(&journalSeq, 1),
+		Kind:     kind,
+		Envelope: envelope,
+	}
+}
+
+func (j *Journal) appendEntry(entry *Entry) {
+	j.mu.Lock()
+	j.pending = append(j.pending, entry)
+	close(j.signal)
+	j.signal = make(chan struct{})
+	j.mu.Unlock()
+}
+
+// Next consumes the oldest entry of any of the given kinds.
+// If there is no such entry yet, it waits for one to appear
+// until the timeout expires.
+func (j *Journal) Next(timeout time.Duration, kinds ...Kind) (*Entry, bool) {
+	deadline := time.After(timeout)
+
+	for {
+		j.mu.Lock()
+		for i, entry := range j.pending {
+			if entry.isOneOf(kinds) {
+				j.pending = append(j.pending[:i], j.pending[i+1:]...)
+				j.mu.Unlock()
+				return entry, true
+			}
+		}
+		signal := j.signal
+		j.mu.Unlock()
+
+		select {
+		case <-signal:
+		case <-deadline:
+			return nil, false
+		}
+	}
+}
+
+// Len returns the number of entries that have not been consumed yet.
+func (j *Journal) Len() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return len(j.pending)
+}
+
+func (entry *Entry) isOneOf(kinds []Kind) bool {
+	for _, kind := range kinds {
+		if entry.Kind == kind {
+			return true
+		}
+	}
+
+	return false
+}
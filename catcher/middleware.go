@@ -1,21 +1,107 @@
 package catcher
 
 import (
+	"sync/atomic"
+
 	"github.com/AsynkronIT/protoactor-go/actor"
 )
 
 func (catcher *Catcher) inboundMiddleware(next actor.ActorFunc) actor.ActorFunc {
 	return func(ctx actor.Context) {
-		catcher.processInboundMessage(ctx)
+		if marker, ok := ctx.Message().(*syncMarker); ok {
+			catcher.pass(marker)
+			return
+		}
+
+		atomic.AddInt32(&catcher.busy, 1)
+		defer atomic.AddInt32(&catcher.busy, -1)
+		atomic.AddUint32(&catcher.handled, 1)
+
+		catcher.recordGoroutine()
+
+		// No marker can go through the mailbox after the last message
+		if _, ok := ctx.Message().(*actor.Stopped); ok {
+			defer catcher.passAll()
+		}
 
 		// Swap the context with a thin wrapper which intercepts some calls.
-		if _, ok := ctx.(*Context); !ok {
-			ctx = NewContext(catcher, ctx)
+		c, ok := ctx.(*Context)
+		if !ok {
+			c = NewContext(catcher, ctx)
+		}
+
+		message := ctx.Message()
+		if catcher.getOptions().Clock != nil {
+			catcher.resetReceiveTimeout(message)
+		}
+
+		if delayed, ok := message.(*delayedMessage); ok {
+			catcher.receive(c.withEnvelope(delayed.envelope), next, true)
+			return
 		}
-		next(ctx)
+
+		if released, ok := message.(*releasedMessage); ok {
+			defer atomic.AddInt32(&catcher.releasing, -1)
+			catcher.receive(c.withEnvelope(released.envelope), next, false)
+			return
+		}
+
+		if _, ok := message.(*actor.Stopping); ok {
+			catcher.flushHeld("the actor stopped")
+			catcher.reportStopping(ctx)
+		} else {
+			atomic.StoreInt32(&catcher.failed, 0)
+		}
+
+		if isSystemMessage(message) {
+			catcher.receive(c, next, false)
+			return
+		}
+
+		catcher.arrive()
+
+		envelope := &Envelope{
+			Sender:  ctx.Sender(),
+			Target:  ctx.Self(),
+			Message: message,
+		}
+
+		catcher.applyFault(false, envelope,
+			func() { catcher.receive(c.withEnvelope(envelope), next, true) },
+			func() { envelope.Target.Tell(&delayedMessage{envelope}) },
+		)
 	}
 }
 
+// receive intercepts a message delivered to the actor and passes it on.
+// With gating, a user message is parked instead, until the test lets it through.
+func (catcher *Catcher) receive(ctx *Context, next actor.ActorFunc, gated bool) {
+	if gated && catcher.getOptions().GatingEnabled {
+		parked := catcher.park(&Envelope{
+			Sender:  ctx.Sender(),
+			Target:  ctx.Self(),
+			Message: ctx.Message(),
+		})
+		if parked {
+			return
+		}
+	}
+
+	catcher.processInboundMessage(ctx)
+
+	defer func() {
+		if reason := recover(); reason != nil {
+			catcher.processFailure(ctx, reason)
+			catcher.reportFailure(ctx, reason)
+
+			// Let Protoactor escalate the failure to the supervisor as usual
+			panic(reason)
+		}
+	}()
+
+	next(ctx)
+}
+
 func (catcher *Catcher) processInboundMessage(ctx actor.Context) {
 	message := ctx.Message()
 
@@ -25,25 +111,77 @@ func (catcher *Catcher) processInboundMessage(ctx actor.Context) {
 		Message: message,
 	}
 
+	opt := catcher.getOptions()
 	if !isSystemMessage(message) {
-		if catcher.Options.InboundInterceptionEnabled {
-			catcher.ChUserInbound <- envelope
+		if opt.InboundInterceptionEnabled && opt.Intercepts(envelope.Sender, envelope.Target, message) {
+			catcher.intercept(KindUserInbound, envelope)
 		}
 	} else {
-		if catcher.Options.SystemInterceptionEnabled {
+		if opt.SystemInterceptionEnabled {
+			// Restart is handled by Protoactor before the middleware,
+			// but it is always followed by Restarting
+			if _, ok := message.(*actor.Restarting); ok {
+				catcher.processSystemMessage(&Envelope{
+					Sender:  ctx.Parent(),
+					Target:  ctx.Self(),
+					Message: &actor.Restart{},
+				})
+			}
+
 			catcher.processSystemMessage(envelope)
 		}
 	}
 }
 
+// processFailure records a panic of the actor as if it was
+// a Failure system message sent by the actor to its supervisor.
+func (catcher *Catcher) processFailure(ctx actor.Context, reason interface{}) {
+	if !catcher.getOptions().SystemInterceptionEnabled {
+		return
+	}
+
+	catcher.processSystemMessage(&Envelope{
+		Sender: ctx.Sender(),
+		Target: ctx.Self(),
+		Message: &actor.Failure{
+			Who:    ctx.Self(),
+			Reason: reason,
+		},
+	})
+}
+
 func (catcher *Catcher) processSystemMessage(envelope *Envelope) {
-	catcher.ChSystemInbound <- envelope
+	catcher.intercept(KindSystemInbound, envelope)
 }
 
 func (catcher *Catcher) outboundMiddleware(next actor.SenderFunc) actor.SenderFunc {
 	return func(ctx actor.Context, target *actor.PID, env actor.MessageEnvelope) {
+		atomic.AddInt32(&catcher.busy, 1)
+		defer atomic.AddInt32(&catcher.busy, -1)
+
 		catcher.processOutboundMessage(ctx, target, env)
-		next(ctx, target, env)
+
+		if isSystemMessage(env.Message) {
+			next(ctx, target, env)
+			return
+		}
+
+		// A message delivered later is sent from another goroutine, where the context
+		// of the actor must not be used. Headers of the message are lost then.
+		catcher.applyFault(true, &Envelope{Sender: ctx.Self(), Target: target, Message: env.Message},
+			func() {
+				catcher.announce(target)
+				next(ctx, target, env)
+			},
+			func() {
+				catcher.announce(target)
+				if env.Sender != nil {
+					target.Request(env.Message, env.Sender)
+				} else {
+					target.Tell(env.Message)
+				}
+			},
+		)
 	}
 }
 
@@ -51,17 +189,124 @@ func (catcher *Catcher) processOutboundMessage(ctx actor.Context, target *actor.
 	// TODO: Is there a difference between using ctx.Message() and env.Message?
 	message := env.Message
 
+	envelope := &Envelope{
+		Sender:  ctx.Self(),
+		Target:  target,
+		Message: message,
+	}
+
+	opt := catcher.getOptions()
 	if !isSystemMessage(message) {
-		catcher.ChUserOutbound <- &Envelope{
-			Sender:  ctx.Self(),
-			Target:  target,
-			Message: message,
+		if opt.OutboundInterceptionEnabled && opt.Intercepts(envelope.Sender, target, message) {
+			catcher.intercept(KindUserOutbound, envelope)
+		}
+	} else {
+		if opt.OutboundSystemInterceptionEnabled {
+			catcher.intercept(KindSystemOutbound, envelope)
 		}
 	}
 }
 
+// intercept hands an envelope over to the test.
+// In the lock-step mode, it blocks until a user message is consumed by an assertion.
+// System and supervision events never block: once the channel's buffer is full,
+// they are queued in order after it. When journaling is enabled,
+// the envelope is recorded and the actor proceeds immediately.
+// Once the catcher is closed, envelopes are dropped.
+// Every envelope is recorded into the history as well.
+func (catcher *Catcher) intercept(kind Kind, envelope *Envelope) {
+	select {
+	case <-catcher.done:
+		return
+	default:
+	}
+
+	entry := catcher.record(kind, envelope)
+
+	if catcher.getOptions().JournalingEnabled {
+		catcher.Journal.appendEntry(entry)
+		return
+	}
+
+	var chEnvelopes chan *Envelope
+	switch kind {
+	case KindUserInbound:
+		chEnvelopes = catcher.ChUserInbound
+	case KindUserOutbound:
+		chEnvelopes = catcher.ChUserOutbound
+	case KindSystemInbound:
+		chEnvelopes = catcher.ChSystemInbound
+	case KindSystemOutbound:
+		chEnvelopes = catcher.ChSystemOutbound
+	case KindSupervision:
+		chEnvelopes = catcher.ChSupervision
+	case KindSpawning:
+		select {
+		case catcher.ChSpawning <- envelope.Target:
+		case <-catcher.done:
+		}
+		return
+	}
+
+	if cap(chEnvelopes) > 0 {
+		catcher.buffer(kind, chEnvelopes, envelope)
+		return
+	}
+
+	select {
+	case chEnvelopes <- envelope:
+	case <-catcher.done:
+	}
+}
+
+// buffer puts an envelope into a buffered channel, or into the overflow queue
+// if the channel is full. Once something is queued, the newer envelopes follow it,
+// so that the channel only holds envelopes older than the queued ones.
+func (catcher *Catcher) buffer(kind Kind, chEnvelopes chan *Envelope, envelope *Envelope) {
+	catcher.overflowMu.Lock()
+	defer catcher.overflowMu.Unlock()
+
+	if len(catcher.overflow[kind]) == 0 {
+		select {
+		case chEnvelopes <- envelope:
+			return
+		default:
+		}
+	}
+
+	if catcher.overflow == nil {
+		catcher.overflow = make(map[Kind][]*Envelope)
+	}
+	catcher.overflow[kind] = append(catcher.overflow[kind], envelope)
+}
+
+// unbuffer takes the oldest envelope of a buffered kind without waiting:
+// first from the channel, then from the overflow queue.
+func (catcher *Catcher) unbuffer(kind Kind, chEnvelopes chan *Envelope) *Envelope {
+	select {
+	case envelope := <-chEnvelopes:
+		return envelope
+	default:
+	}
+
+	catcher.overflowMu.Lock()
+	defer catcher.overflowMu.Unlock()
+
+	envelopes := catcher.overflow[kind]
+	if len(envelopes) == 0 {
+		return nil
+	}
+
+	catcher.overflow[kind] = envelopes[1:]
+	return envelopes[0]
+}
+
+// ReceiveTimeout is a user message for Protoactor,
+// but it is a part of the actor lifecycle for the tests.
 func isSystemMessage(msg interface{}) bool {
 	switch msg.(type) {
+	case *actor.ReceiveTimeout:
+		return true
 	case actor.AutoReceiveMessage:
 		return true
 	case actor.SystemMessage:
@@ -0,0 +1,151 @@
+package catcher
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/meamidos/gopactor/matchers"
+)
+
+// AssertAll collects envelopes of a given kind until every expected message
+// is matched by a distinct envelope, in any order. An expected message can be
+// a matcher, or an *Envelope to check the sender or the target as well.
+// Envelopes that are not expected are consumed and skipped, unless strict is set:
+// then the first one fails the assertion right away.
+func (catcher *Catcher) AssertAll(kind Kind, expected []interface{}, strict bool, timeout time.Duration) error {
+	timeout = catcher.timeout(timeout)
+	deadline := time.Now().Add(timeout)
+
+	set := &messageSet{expected: expected}
+	for !set.satisfied() {
+		left := deadline.Sub(time.Now())
+		if left <= 0 {
+			return set.failure(fmt.Sprintf("Timeout %s while waiting for %s", timeout, kindDescriptions[kind]), timeout)
+		}
+
+		envelope, ok := catcher.Next(kind, left)
+		if !ok {
+			return set.failure(fmt.Sprintf("Timeout %s while waiting for %s", timeout, kindDescriptions[kind]), timeout)
+		}
+
+		if !set.add(envelope) && strict {
+			return set.failure("Got an unexpected message", 0)
+		}
+	}
+
+	return nil
+}
+
+// messageSet matches collected envelopes against expected messages.
+// As expectations may overlap (think of matchers), the best assignment
+// is recomputed on every new envelope, so that the order of arrival
+// does not matter.
+type messageSet struct {
+	expected  []interface{}
+	envelopes []*Envelope
+
+	// The index of the envelope assigned to each expectation, or -1
+	assigned []int
+	matched  int
+}
+
+// add collects an envelope and tells whether it has been assigned to an expectation.
+func (set *messageSet) add(envelope *Envelope) bool {
+	set.envelopes = append(set.envelopes, envelope)
+
+	set.assigned = make([]int, len(set.expected))
+	for i := range set.assigned {
+		set.assigned[i] = -1
+	}
+
+	set.matched = 0
+	for e := range set.envelopes {
+		if set.augment(e, make([]bool, len(set.expected))) {
+			set.matched++
+		}
+	}
+
+	last := len(set.envelopes) - 1
+	for _, e := range set.assigned {
+		if e == last {
+			return true
+		}
+	}
+
+	return false
+}
+
+// augment tries to assign an envelope to an expectation, reassigning
+// previously assigned envelopes if needed (Kuhn's algorithm).
+func (set *messageSet) augment(e int, visited []bool) bool {
+	for i, expected := range set.expected {
+		if visited[i] || !expectedMatches(expected, set.envelopes[e]) {
+			continue
+		}
+		visited[i] = true
+
+		if set.assigned[i] < 0 || set.augment(set.assigned[i], visited) {
+			set.assigned[i] = e
+			return true
+		}
+	}
+
+	return false
+}
+
+func (set *messageSet) satisfied() bool {
+	return set.matched == len(set.expected)
+}
+
+func (set *messageSet) failure(reason string, timeout time.Duration) *Failure {
+	failure := &Failure{
+		Reason:     reason,
+		Timeout:    timeout,
+		Met:        []interface{}{},
+		Missing:    []interface{}{},
+		Unexpected: []*Envelope{},
+	}
+
+	used := make([]bool, len(set.envelopes))
+	for i, expected := range set.expected {
+		if set.assigned != nil && set.assigned[i] >= 0 {
+			used[set.assigned[i]] = true
+			failure.Met = append(failure.Met, expected)
+		} else {
+			failure.Missing = append(failure.Missing, expected)
+		}
+	}
+
+	for e, envelope := range set.envelopes {
+		if !used[e] {
+			failure.Unexpected = append(failure.Unexpected, envelope)
+		}
+	}
+
+	return failure
+}
+
+func expectedMatches(expected interface{}, envelope *Envelope) bool {
+	if spec, ok := expected.(*Envelope); ok {
+		return envelopeMatches(envelope, spec.Message, spec.Sender, spec.Target)
+	}
+
+	return messagesMatch(envelope.Message, expected)
+}
+
+func (envelope *Envelope) describe() string {
+	description := "any message"
+	if envelope.Message != nil {
+		description = matchers.Describe(envelope.Message)
+	}
+
+	if envelope.Sender != nil {
+		description += fmt.Sprintf(" from %v", envelope.Sender)
+	}
+
+	if envelope.Target != nil {
+		description += fmt.Sprintf(" to %v", envelope.Target)
+	}
+
+	return description
+}
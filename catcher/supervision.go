@@ -0,0 +1,211 @@
+package catcher
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+// Decision is a directive chosen by a supervisor strategy
+// of the followed actor to handle a failure of one of its children.
+type Decision struct {
+	Child     *actor.PID
+	Reason    interface{}
+	Directive actor.Directive
+}
+
+func (d *Decision) String() string {
+	return fmt.Sprintf("%s %v (reason: %v)", DirectiveName(d.Directive), d.Child, d.Reason)
+}
+
+// DirectiveName returns a human-readable name of a supervisor directive.
+func DirectiveName(directive actor.Directive) string {
+	switch directive {
+	case actor.ResumeDirective:
+		return "Resume"
+	case actor.RestartDirective:
+		return "Restart"
+	case actor.StopDirective:
+		return "Stop"
+	case actor.EscalateDirective:
+		return "Escalate"
+	}
+
+	return fmt.Sprintf("Directive(%d)", directive)
+}
+
+// recordingStrategy wraps a real supervisor strategy and records
+// every decision it makes about the children of the followed actor,
+// if supervision is recorded. With system interception, it also records
+// the failures of the children as Failure system messages received by
+// the followed actor, unless the children are followed and report
+// their failures themselves. Followed children are told when they are
+// resumed or restarted, so that a later stop is not taken for the decision.
+type recordingStrategy struct {
+	catcher  *Catcher
+	strategy actor.SupervisorStrategy
+}
+
+func (s *recordingStrategy) HandleFailure(supervisor actor.Supervisor, child *actor.PID, rs *actor.RestartStatistics, reason interface{}, message interface{}) {
+	opt := s.catcher.getOptions()
+	if opt.SupervisionRecordingEnabled && opt.SystemInterceptionEnabled && !s.catcher.follows(child) {
+		s.catcher.intercept(KindSystemInbound, &Envelope{
+			Sender: child,
+			Target: s.catcher.getAssignedActor(),
+			Message: &actor.Failure{
+				Who:          child,
+				Reason:       reason,
+				RestartStats: rs,
+				Message:      message,
+			},
+		})
+	}
+
+	recorder := &recordingSupervisor{
+		Supervisor: supervisor,
+		catcher:    s.catcher,
+		child:      child,
+		reason:     reason,
+	}
+
+	s.strategy.HandleFailure(recorder, child, rs, reason, message)
+}
+
+// recordingSupervisor sees which directive the strategy applies
+// by intercepting the calls the strategy makes to the supervisor.
+type recordingSupervisor struct {
+	actor.Supervisor
+
+	catcher *Catcher
+	child   *actor.PID
+	reason  interface{}
+}
+
+func (s *recordingSupervisor) ResumeChildren(pids ...*actor.PID) {
+	s.record(actor.ResumeDirective, pids...)
+	s.clearFailures(pids...)
+	s.Supervisor.ResumeChildren(pids...)
+}
+
+func (s *recordingSupervisor) RestartChildren(pids ...*actor.PID) {
+	s.record(actor.RestartDirective, pids...)
+	s.clearFailures(pids...)
+	s.Supervisor.RestartChildren(pids...)
+}
+
+func (s *recordingSupervisor) StopChildren(pids ...*actor.PID) {
+	s.record(actor.StopDirective, pids...)
+
+	// The children are stopped on behalf of the followed actor
+	opt := s.catcher.getOptions()
+	if opt.SupervisionRecordingEnabled && opt.OutboundSystemInterceptionEnabled {
+		for _, pid := range pids {
+			if s.catcher.follows(pid) {
+				continue
+			}
+			s.catcher.intercept(KindSystemOutbound, &Envelope{
+				Sender:  s.catcher.getAssignedActor(),
+				Target:  pid,
+				Message: &actor.Stop{},
+			})
+		}
+	}
+
+	s.Supervisor.StopChildren(pids...)
+}
+
+func (s *recordingSupervisor) EscalateFailure(reason interface{}, message interface{}) {
+	s.record(actor.EscalateDirective, s.child)
+	s.Supervisor.EscalateFailure(reason, message)
+}
+
+// clearFailures clears the failures of followed children, which are not going to be stopped
+func (s *recordingSupervisor) clearFailures(pids ...*actor.PID) {
+	if s.catcher.Registry == nil {
+		return
+	}
+
+	for _, pid := range pids {
+		if child := s.catcher.Registry.Lookup(pid); child != nil {
+			atomic.StoreInt32(&child.failed, 0)
+		}
+	}
+}
+
+func (s *recordingSupervisor) record(directive actor.Directive, pids ...*actor.PID) {
+	if !s.catcher.getOptions().SupervisionRecordingEnabled {
+		return
+	}
+
+	for _, pid := range pids {
+		s.catcher.intercept(KindSupervision, &Envelope{
+			Sender: s.catcher.getAssignedActor(),
+			Target: pid,
+			Message: &Decision{
+				Child:     pid,
+				Reason:    s.reason,
+				Directive: directive,
+			},
+		})
+	}
+}
+
+// Failures of followed children and their stopping by the supervisor
+// are reported by the children to the catcher of the parent,
+// if it is followed by the same registry. So, they can be asserted
+// without replacing the supervisor strategy of the parent.
+
+// reportFailure hands a failure of the actor over to the catcher of its parent
+func (catcher *Catcher) reportFailure(ctx actor.Context, reason interface{}) {
+	atomic.StoreInt32(&catcher.failed, 1)
+
+	parent := catcher.parentCatcher(ctx)
+	if parent == nil || !parent.getOptions().SystemInterceptionEnabled {
+		return
+	}
+
+	parent.intercept(KindSystemInbound, &Envelope{
+		Sender: ctx.Self(),
+		Target: ctx.Parent(),
+		Message: &actor.Failure{
+			Who:     ctx.Self(),
+			Reason:  reason,
+			Message: ctx.Message(),
+		},
+	})
+}
+
+// reportStopping tells the catcher of the parent that the actor is stopped
+// by the supervisor, i.e. it is stopping after a failure, and the supervisor
+// has not resumed or restarted it. A Resume can only be seen if the strategy
+// of the parent is given in the options, or supervision is recorded.
+func (catcher *Catcher) reportStopping(ctx actor.Context) {
+	if atomic.SwapInt32(&catcher.failed, 0) == 0 {
+		return
+	}
+
+	parent := catcher.parentCatcher(ctx)
+	if parent == nil || !parent.getOptions().OutboundSystemInterceptionEnabled {
+		return
+	}
+
+	parent.intercept(KindSystemOutbound, &Envelope{
+		Sender:  ctx.Parent(),
+		Target:  ctx.Self(),
+		Message: &actor.Stop{},
+	})
+}
+
+func (catcher *Catcher) parentCatcher(ctx actor.Context) *Catcher {
+	if catcher.Registry == nil || ctx.Parent() == nil {
+		return nil
+	}
+
+	return catcher.Registry.Lookup(ctx.Parent())
+}
+
+// follows tells whether another actor is followed by the same registry
+func (catcher *Catcher) follows(pid *actor.PID) bool {
+	return catcher.Registry != nil && catcher.Registry.Lookup(pid) != nil
+}
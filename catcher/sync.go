@@ -0,0 +1,69 @@
+package catcher
+
+import "sync/atomic"
+
+// syncMarker is sent through the mailbox of the actor by Sync.
+// The middleware lets it pass without handing it over to the actor.
+type syncMarker struct {
+	passed chan struct{}
+}
+
+// The marker is not a message for the actor, so its receive timeout stays as is
+func (*syncMarker) NotInfluenceReceiveTimeout() {}
+
+// Handled returns the number of messages of any kind
+// the followed actor has started handling so far.
+func (catcher *Catcher) Handled() uint32 {
+	return atomic.LoadUint32(&catcher.handled)
+}
+
+// Sync sends a marker through the mailbox of the followed actor.
+// The returned channel is closed once the marker has passed, i.e. the actor
+// has handled every message put into its mailbox before. It is closed as well
+// once the actor has stopped, or the catcher has been closed.
+// If the actor is spawned without the inbound middleware, it is closed right away.
+func (catcher *Catcher) Sync() <-chan struct{} {
+	marker := &syncMarker{passed: make(chan struct{})}
+
+	pid := catcher.getAssignedActor()
+
+	catcher.syncMu.Lock()
+	if pid == nil || catcher.stopped || !catcher.needsInboundMiddleware(catcher.getOptions()) {
+		catcher.syncMu.Unlock()
+		close(marker.passed)
+		return marker.passed
+	}
+
+	if catcher.syncing == nil {
+		catcher.syncing = make(map[*syncMarker]bool)
+	}
+	catcher.syncing[marker] = true
+	catcher.syncMu.Unlock()
+
+	pid.Tell(marker)
+	return marker.passed
+}
+
+// pass closes the channel of a marker which has gone through the mailbox
+func (catcher *Catcher) pass(marker *syncMarker) {
+	catcher.syncMu.Lock()
+	defer catcher.syncMu.Unlock()
+
+	if catcher.syncing[marker] {
+		delete(catcher.syncing, marker)
+		close(marker.passed)
+	}
+}
+
+// passAll closes the channels of all markers, since they can not go through
+// the mailbox anymore. Markers sent afterwards pass right away.
+func (catcher *Catcher) passAll() {
+	catcher.syncMu.Lock()
+	defer catcher.syncMu.Unlock()
+
+	catcher.stopped = true
+	for marker := range catcher.syncing {
+		close(marker.passed)
+	}
+	catcher.syncing = nil
+}
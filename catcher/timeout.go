@@ -0,0 +1,62 @@
+package catcher
+
+import (
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+// setReceiveTimeout schedules the receive timeout of the actor on the clock of the options.
+// Like in Protoactor, setting the same duration again changes nothing,
+// and a zero duration turns the timeout off.
+func (catcher *Catcher) setReceiveTimeout(self *actor.PID, d time.Duration) {
+	catcher.timeoutMu.Lock()
+	defer catcher.timeoutMu.Unlock()
+
+	if d == catcher.receiveTimeout {
+		return
+	}
+
+	if catcher.timeoutTimer != nil {
+		catcher.timeoutTimer.Stop()
+		catcher.timeoutTimer = nil
+	}
+
+	catcher.receiveTimeout = d
+	catcher.timeoutSelf = self
+	if d > 0 {
+		catcher.scheduleReceiveTimeout()
+	}
+}
+
+func (catcher *Catcher) getReceiveTimeout() time.Duration {
+	catcher.timeoutMu.Lock()
+	defer catcher.timeoutMu.Unlock()
+	return catcher.receiveTimeout
+}
+
+// resetReceiveTimeout starts the receive timeout over when a message arrives,
+// unless the message should not influence it
+func (catcher *Catcher) resetReceiveTimeout(msg interface{}) {
+	if _, ok := msg.(actor.NotInfluenceReceiveTimeout); ok {
+		return
+	}
+
+	catcher.timeoutMu.Lock()
+	defer catcher.timeoutMu.Unlock()
+
+	if catcher.timeoutTimer == nil {
+		return
+	}
+
+	catcher.timeoutTimer.Stop()
+	catcher.scheduleReceiveTimeout()
+}
+
+// The lock must be held
+func (catcher *Catcher) scheduleReceiveTimeout() {
+	self := catcher.timeoutSelf
+	catcher.timeoutTimer = catcher.getOptions().Clock.AfterFunc(catcher.receiveTimeout, func() {
+		self.Tell(&actor.ReceiveTimeout{})
+	})
+}
@@ -0,0 +1,168 @@
+// Package clock lets tests control the time seen by actors.
+// With a virtual clock, receive timeouts of intercepted actors and messages
+// scheduled with a clock.Scheduler fire when the test advances the clock,
+// not when real time passes. Timers of the actors not made with the clock,
+// e.g. the scheduler of Protoactor or time.AfterFunc, still use real time:
+// such actors should be given a clock.Scheduler instead.
+// Timeouts of assertions are not affected, they are always real.
+//
+// Example:
+//
+//   clk := clock.NewVirtual(time.Now())
+//   session, _ := SpawnFromInstance(&Session{Scheduler: clock.NewScheduler(clk)}, OptDefault.WithClock(clk))
+//
+//   clk.Advance(5 * time.Minute)
+//   So(session, ShouldReceiveTimeout)
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock tells the time and runs functions after a delay
+type Clock interface {
+	Now() time.Time
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a function scheduled by a clock.
+// Stop returns false if the function has already run or has been stopped.
+type Timer interface {
+	Stop() bool
+}
+
+// Real is the clock of the time package
+var Real Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// Virtual is a clock which only moves when the test advances it.
+// Functions become due when the clock reaches their time, and they run
+// in order of their time, in the goroutine which advances the clock.
+type Virtual struct {
+	mu     sync.Mutex
+	now    time.Time
+	seq    uint64
+	timers []*virtualTimer
+}
+
+type virtualTimer struct {
+	clock *Virtual
+	when  time.Time
+	seq   uint64
+	f     func()
+}
+
+// NewVirtual creates a virtual clock showing a given time
+func NewVirtual(now time.Time) *Virtual {
+	return &Virtual{now: now}
+}
+
+// Now returns the virtual time
+func (v *Virtual) Now() time.Time {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.now
+}
+
+// AfterFunc schedules a function to run when the clock is advanced by the delay
+func (v *Virtual) AfterFunc(d time.Duration, f func()) Timer {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.seq++
+	t := &virtualTimer{clock: v, when: v.now.Add(d), seq: v.seq, f: f}
+	v.timers = append(v.timers, t)
+	return t
+}
+
+// Advance moves the clock forward and runs the functions which become due,
+// including the ones scheduled by them in the meantime. The clock shows
+// the time of every function while it runs. Advance may be called
+// concurrently or from a function run by the clock: the time never goes back.
+func (v *Virtual) Advance(d time.Duration) {
+	v.mu.Lock()
+	end := v.now.Add(d)
+	v.mu.Unlock()
+
+	for {
+		t := v.nextDue(end)
+		if t == nil {
+			break
+		}
+		t.f()
+	}
+
+	v.mu.Lock()
+	if end.After(v.now) {
+		v.now = end
+	}
+	v.mu.Unlock()
+}
+
+// Pending returns the number of scheduled functions which have not run yet
+func (v *Virtual) Pending() int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return len(v.timers)
+}
+
+// nextDue removes the earliest function due by the end, and moves the clock to its time
+func (v *Virtual) nextDue(end time.Time) *virtualTimer {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if len(v.timers) == 0 {
+		return nil
+	}
+
+	sort.Sort(byWhen(v.timers))
+	t := v.timers[0]
+	if t.when.After(end) {
+		return nil
+	}
+
+	v.timers = v.timers[1:]
+	if t.when.After(v.now) {
+		v.now = t.when
+	}
+
+	return t
+}
+
+func (t *virtualTimer) Stop() bool {
+	v := t.clock
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for i, other := range v.timers {
+		if other == t {
+			v.timers = append(v.timers[:i], v.timers[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+type byWhen []*virtualTimer
+
+func (s byWhen) Len() int      { return len(s) }
+func (s byWhen) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byWhen) Less(i, j int) bool {
+	if s[i].when.Equal(s[j].when) {
+		return s[i].seq < s[j].seq
+	}
+	return s[i].when.Before(s[j].when)
+}
@@ -0,0 +1,136 @@
+package clock_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVirtual(t *testing.T) {
+	a := assert.New(t)
+
+	start := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := clock.NewVirtual(start)
+
+	fired := []string{}
+	at := func(name string) func() {
+		return func() { fired = append(fired, name+" "+clk.Now().Sub(start).String()) }
+	}
+
+	clk.AfterFunc(2*time.Hour, at("second"))
+	clk.AfterFunc(time.Hour, func() {
+		at("first")()
+		clk.AfterFunc(30*time.Minute, at("nested"))
+	})
+	clk.AfterFunc(time.Hour, at("tie"))
+	stopped := clk.AfterFunc(time.Minute, at("stopped"))
+
+	// Nothing runs until the clock is advanced
+	a.True(stopped.Stop())
+	a.False(stopped.Stop())
+	a.Equal(3, clk.Pending())
+	a.Empty(fired)
+
+	// Due functions run in order of their time, then of scheduling
+	clk.Advance(90 * time.Minute)
+	a.Equal([]string{"first 1h0m0s", "tie 1h0m0s", "nested 1h30m0s"}, fired)
+	a.Equal(start.Add(90*time.Minute), clk.Now())
+	a.Equal(1, clk.Pending())
+
+	clk.Advance(time.Hour)
+	a.Equal("second 2h0m0s", fired[3])
+	a.Equal(start.Add(150*time.Minute), clk.Now())
+	a.Equal(0, clk.Pending())
+}
+
+func TestScheduler(t *testing.T) {
+	a := assert.New(t)
+
+	received := make(chan string, 10)
+	pid := actor.Spawn(actor.FromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(string); ok {
+			received <- msg
+		}
+	}))
+	defer pid.Stop()
+
+	clk := clock.NewVirtual(time.Now())
+	scheduler := clock.NewScheduler(clk)
+
+	scheduler.SendOnce(time.Minute, pid, "once")
+	cancel := scheduler.SendRepeatedly(time.Second, time.Hour, pid, "tick")
+
+	clk.Advance(2 * time.Hour)
+	messages := []string{<-received, <-received, <-received}
+	a.Equal([]string{"tick", "once", "tick"}, messages)
+
+	// Cancelled messages are not sent anymore
+	cancel()
+	a.Equal(0, clk.Pending())
+	clk.Advance(24 * time.Hour)
+
+	select {
+	case msg := <-received:
+		a.Fail("Unexpected message", msg)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestVirtual_ConcurrentAdvance(t *testing.T) {
+	a := assert.New(t)
+
+	start := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := clock.NewVirtual(start)
+
+	// A function run by the clock advances it further than the caller
+	nested := clock.NewVirtual(start)
+	nested.AfterFunc(time.Second, func() { nested.Advance(time.Hour) })
+	nested.Advance(2 * time.Second)
+	a.Equal(start.Add(time.Hour+time.Second), nested.Now())
+
+	var mu sync.Mutex
+	late := 0
+	for i := 1; i <= 100; i++ {
+		due := start.Add(time.Duration(i) * time.Second)
+		clk.AfterFunc(time.Duration(i)*time.Second, func() {
+			// Also from a function run by the clock
+			clk.Advance(time.Millisecond)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if clk.Now().Before(due) {
+				late++
+			}
+		})
+	}
+
+	var wg sync.WaitGroup
+	backwards := make(chan time.Time, 100)
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			last := clk.Now()
+			for i := 0; i < 50; i++ {
+				clk.Advance(time.Second)
+				if now := clk.Now(); now.Before(last) {
+					backwards <- now
+				} else {
+					last = now
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(backwards)
+
+	a.Empty(backwards)
+	a.Equal(0, late)
+	a.Equal(0, clk.Pending())
+	a.False(clk.Now().Before(start.Add(100 * time.Second)))
+}
@@ -0,0 +1,69 @@
+package clock
+
+import (
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+// CancelFunc stops scheduled messages
+type CancelFunc func()
+
+// Scheduler sends messages to actors after a delay, or repeatedly.
+// It follows the API of the Protoactor scheduler, so that an actor
+// can be given the real or the virtual one. Gopactor does not intercept
+// the scheduler of Protoactor: only messages scheduled with this one
+// follow a virtual clock.
+type Scheduler struct {
+	clock Clock
+}
+
+// NewScheduler creates a scheduler driven by a clock
+func NewScheduler(c Clock) *Scheduler {
+	return &Scheduler{clock: c}
+}
+
+// SendOnce tells the message to the actor after the delay
+func (s *Scheduler) SendOnce(delay time.Duration, pid *actor.PID, message interface{}) CancelFunc {
+	t := s.clock.AfterFunc(delay, func() {
+		pid.Tell(message)
+	})
+
+	return func() {
+		t.Stop()
+	}
+}
+
+// SendRepeatedly tells the message to the actor after the initial delay,
+// and then every interval, until cancelled
+func (s *Scheduler) SendRepeatedly(initial, interval time.Duration, pid *actor.PID, message interface{}) CancelFunc {
+	var mu sync.Mutex
+	cancelled := false
+	var t Timer
+
+	var tick func()
+	tick = func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if cancelled {
+			return
+		}
+
+		pid.Tell(message)
+		t = s.clock.AfterFunc(interval, tick)
+	}
+
+	mu.Lock()
+	t = s.clock.AfterFunc(initial, tick)
+	mu.Unlock()
+
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		cancelled = true
+		t.Stop()
+	}
+}
@@ -0,0 +1,64 @@
+package gopactor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVirtualClock(t *testing.T) {
+	a := assert.New(t)
+
+	clk := clock.NewVirtual(time.Now())
+	start := clk.Now()
+
+	active := make(chan time.Duration, 10)
+	expired := make(chan bool, 10)
+	session, _ := SpawnFromFunc(func(ctx actor.Context) {
+		switch ctx.Message().(type) {
+		case *actor.Started:
+			ctx.SetReceiveTimeout(time.Hour)
+			active <- ctx.ReceiveTimeout()
+		case string:
+			active <- ctx.ReceiveTimeout()
+		case *actor.ReceiveTimeout:
+			ctx.SetReceiveTimeout(0)
+			expired <- true
+		}
+	}, OptNoInterception.WithSystemInterception().WithJournaling().WithClock(clk).WithTimeout(time.Second))
+
+	a.Equal(time.Hour, <-active)
+
+	// Real time does not matter
+	clk.Advance(59 * time.Minute)
+	a.False(receivedWithin(expired, 10*time.Millisecond))
+
+	// Every message starts the timeout over
+	session.Tell("touch")
+	a.Equal(time.Hour, <-active)
+	clk.Advance(59 * time.Minute)
+	a.False(receivedWithin(expired, 10*time.Millisecond))
+
+	clk.Advance(time.Minute)
+	a.Empty(ShouldReceiveTimeout(session))
+	a.True(receivedWithin(expired, time.Second))
+	a.Equal(start.Add(119*time.Minute), clk.Now())
+
+	// The timeout is turned off
+	a.Equal(0, clk.Pending())
+
+	// Cleanup
+	PactReset()
+}
+
+func receivedWithin(ch chan bool, timeout time.Duration) bool {
+	select {
+	case <-ch:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
@@ -0,0 +1,108 @@
+package gopactor
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+// These tests are meant to be run with the race detector:
+//   go test -race
+
+const stressWorkers = 50
+
+func TestConcurrency_SpawnAndAssert(t *testing.T) {
+	a := assert.New(t)
+
+	results := make(chan string, stressWorkers*2)
+	var wg sync.WaitGroup
+	for i := 0; i < stressWorkers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			// A generous timeout: the race detector slows everything down.
+			receiver, err := SpawnFromFunc(func(ctx actor.Context) {
+				if _, ok := ctx.Message().(int); ok {
+					ctx.Respond("done")
+				}
+			}, OptDefault.WithPrefix("rcv").WithTimeout(time.Second))
+			if err != nil {
+				results <- err.Error()
+				return
+			}
+
+			requestor, err := SpawnNullActor(OptDefault.WithPrefix("req").WithTimeout(time.Second))
+			if err != nil {
+				results <- err.Error()
+				return
+			}
+
+			receiver.Request(i, requestor)
+			results <- ShouldReceiveFrom(receiver, requestor, i)
+			results <- ShouldSendTo(receiver, requestor, "done")
+		}(i)
+	}
+
+	wg.Wait()
+	close(results)
+	for res := range results {
+		a.Empty(res)
+	}
+
+	// Cleanup
+	PactReset()
+}
+
+func TestConcurrency_ParallelSubtests(t *testing.T) {
+	for i := 0; i < stressWorkers; i++ {
+		i := i
+		t.Run(fmt.Sprintf("worker-%d", i), func(t *testing.T) {
+			t.Parallel()
+			a := assert.New(t)
+
+			receiver, err := SpawnFromFunc(func(ctx actor.Context) {},
+				OptDefault.WithSystemInterception().WithTimeout(time.Second))
+			a.Nil(err)
+
+			a.Empty(ShouldStart(receiver))
+
+			receiver.Tell(i)
+			a.Empty(ShouldReceive(receiver, i))
+
+			receiver.Stop()
+			a.Empty(ShouldStop(receiver))
+		})
+	}
+}
+
+func TestConcurrency_ResetWhileAsserting(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < stressWorkers; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			receiver, err := SpawnNullActor(OptNoInterception)
+			if err == nil {
+				// Whether the receiver is still registered depends on timing.
+				// Only the absence of data races matters here.
+				ShouldNotSendOrReceive(receiver)
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+			PactReset()
+		}()
+	}
+
+	wg.Wait()
+
+	// Cleanup
+	PactReset()
+}
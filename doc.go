@@ -16,7 +16,8 @@ Intercept system messages
 
 Protoactor uses special system messages to control the lifecycle of an actor.
 Gopactor can intercept some of such messages to help you ensure that your actor
-stops or restarts when expected.
+stops or restarts when expected. It also notices when your actor panics,
+so you can assert that it fails, and what it fails with.
 
 Intercept spawning of children
 
@@ -0,0 +1,76 @@
+package gopactor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/catcher"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpect(t *testing.T) {
+	a := assert.New(t)
+
+	requestor, _ := SpawnNullActor(OptNoInterception)
+	worker, _ := SpawnFromFunc(func(ctx actor.Context) {
+		switch ctx.Message() {
+		case "ping":
+			ctx.Respond("pong")
+		case "spawn":
+			ctx.SpawnPrefix(actor.FromFunc(func(actor.Context) {}), "child")
+		}
+	}, OptDefault.WithSpawnInterception())
+
+	// Nothing to check
+	a.Contains(Expect(worker).Verify().Error(), "Nothing is expected")
+
+	// Unknown actors
+	a.Contains(Expect(nil).ToReceive("ping").Verify().Error(), "Receiver is not an actor PID")
+	a.Contains(Expect(actor.NewLocalPID("unknown")).ToSend("ping").Verify().Error(), "Sender is not registered")
+
+	// Success
+	worker.Request("ping", requestor)
+	a.Nil(Expect(worker).ToReceive("ping").From(requestor).Within(time.Second))
+	a.Nil(Expect(worker).ToSend("pong").To(requestor).Verify())
+	a.Nil(Expect(worker).NotToSendOrReceive().Verify())
+
+	// Structured failure: mismatch
+	worker.Request("ping", requestor)
+	err := Expect(worker).ToReceive("hello").Verify()
+	failure, ok := err.(*catcher.Failure)
+	a.True(ok)
+	a.Equal("Messages do not match", failure.Reason)
+	a.Equal(`"hello"`, failure.Expected)
+	a.Equal(`"ping"`, failure.Actual)
+	a.Equal(requestor, failure.Envelope.Sender)
+	a.Zero(failure.Timeout)
+	a.Nil(Expect(worker).ToSend(MatchType("")).Verify())
+
+	// Structured failure: timeout
+	err = Expect(worker).ToReceive("ping").Within(5 * time.Millisecond)
+	failure, ok = err.(*catcher.Failure)
+	a.True(ok)
+	a.Equal(5*time.Millisecond, failure.Timeout)
+	a.Nil(failure.Envelope)
+
+	// Structured failure: forbidden message
+	worker.Tell("hello")
+	err = Expect(worker).NotToReceive("hello").Verify()
+	failure, ok = err.(*catcher.Failure)
+	a.True(ok)
+	a.Equal("hello", failure.Envelope.Message)
+
+	// Spawning
+	worker.Tell("spawn")
+	a.Nil(Expect(worker).ToReceive("spawn").Verify())
+	a.Nil(Expect(worker).ToSpawn("child").Verify())
+	a.Nil(Expect(worker).NotToSpawn("").Verify())
+
+	// Lifecycle
+	worker.Stop()
+	a.Nil(Expect(worker).NotToFail().Verify())
+
+	// Cleanup
+	PactReset()
+}
@@ -0,0 +1,187 @@
+package gopactor
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/gopactor"
+	"github.com/stretchr/testify/assert"
+)
+
+type setCounter struct{ Value int64 }
+
+// Two clients increment a shared counter. With read-modify-write,
+// an update is lost when both clients read before either writes.
+func counterScenario(counter *int64, readModifyWrite bool) gopactor.Scenario {
+	return func(p *gopactor.Gopactor) {
+		atomic.StoreInt64(counter, 0)
+		opt := OptNoInterception.WithGating()
+
+		store, _ := p.SpawnFromFunc(func(ctx actor.Context) {
+			switch msg := ctx.Message().(type) {
+			case string:
+				if msg == "get" {
+					ctx.Respond(atomic.LoadInt64(counter))
+				} else if msg == "inc" {
+					atomic.AddInt64(counter, 1)
+				}
+			case *setCounter:
+				atomic.StoreInt64(counter, msg.Value)
+			}
+		}, opt.WithPrefix("store"))
+
+		client := func(ctx actor.Context) {
+			switch msg := ctx.Message().(type) {
+			case string:
+				if !readModifyWrite {
+					ctx.Tell(store, "inc")
+				} else {
+					ctx.Request(store, "get")
+				}
+			case int64:
+				ctx.Tell(store, &setCounter{Value: msg + 1})
+			}
+		}
+
+		for i := 0; i < 2; i++ {
+			pid, _ := p.SpawnFromFunc(client, opt.WithPrefix("client"))
+			pid.Tell("start")
+		}
+	}
+}
+
+func counterInvariant(counter *int64) gopactor.Invariant {
+	return func(p *gopactor.Gopactor) error {
+		if value := atomic.LoadInt64(counter); value != 2 {
+			return fmt.Errorf("Counter is %d instead of 2", value)
+		}
+		return nil
+	}
+}
+
+func TestExplore(t *testing.T) {
+	a := assert.New(t)
+
+	var counter int64
+	opt := gopactor.ExploreOptions{}
+
+	// Atomic increments are safe in any order
+	coverage, err := Explore(counterScenario(&counter, false), counterInvariant(&counter), opt)
+	a.Nil(err)
+	a.True(coverage.Complete, coverage.String())
+	a.True(coverage.Runs > 1)
+
+	// A lost update is found
+	_, err = Explore(counterScenario(&counter, true), counterInvariant(&counter), opt)
+	counterexample, ok := err.(*gopactor.Counterexample)
+	if !a.True(ok, "%v", err) {
+		return
+	}
+	a.False(counterexample.Random)
+	a.Equal("Counter is 1 instead of 2", counterexample.Err.Error())
+	a.Contains(counterexample.Error(), "Schedule:")
+
+	// And it can be replayed
+	opt.Replay = counterexample.Choices
+	_, err = Explore(counterScenario(&counter, true), counterInvariant(&counter), opt)
+	replayed, ok := err.(*gopactor.Counterexample)
+	if a.True(ok, "%v", err) {
+		a.Equal(counterexample.Choices, replayed.Choices)
+		a.Equal(len(counterexample.Schedule), len(replayed.Schedule))
+	}
+
+	// Random schedules find it as well, and report the seed
+	coverage, err = Explore(counterScenario(&counter, true), counterInvariant(&counter), gopactor.ExploreOptions{
+		Random: true,
+		Seed:   42,
+	})
+	a.True(coverage.Random)
+	a.False(coverage.Complete)
+	counterexample, ok = err.(*gopactor.Counterexample)
+	if a.True(ok, "%v", err) {
+		a.True(counterexample.Random)
+		a.True(counterexample.Seed >= 42)
+		a.Contains(counterexample.Error(), fmt.Sprintf("seed %d", counterexample.Seed))
+	}
+}
+
+func TestExplore_SameActor(t *testing.T) {
+	a := assert.New(t)
+
+	var first atomic.Value
+	scenario := func(p *gopactor.Gopactor) {
+		first = atomic.Value{}
+		log, _ := p.SpawnFromFunc(func(ctx actor.Context) {
+			if msg, ok := ctx.Message().(string); ok && first.Load() == nil {
+				first.Store(msg)
+			}
+		}, OptNoInterception.WithGating())
+
+		log.Tell("open")
+		log.Tell("write")
+	}
+	invariant := func(p *gopactor.Gopactor) error {
+		if first.Load() != "open" {
+			return fmt.Errorf("Written before open")
+		}
+		return nil
+	}
+
+	// Messages queued at the same actor are delivered in every order
+	_, err := Explore(scenario, invariant)
+	counterexample, ok := err.(*gopactor.Counterexample)
+	if a.True(ok, "%v", err) {
+		a.Equal([]gopactor.Step{{Actor: 0, Message: 1}, {Actor: 0, Message: 0}}, counterexample.Choices)
+	}
+}
+
+func TestExplore_Incomplete(t *testing.T) {
+	a := assert.New(t)
+
+	var counter int64
+
+	// Too many schedules for the limit: only a sample is tried
+	coverage, err := Explore(counterScenario(&counter, false), counterInvariant(&counter), gopactor.ExploreOptions{MaxRuns: 2})
+	a.Nil(err)
+	a.False(coverage.Complete)
+	a.True(coverage.Random)
+	a.Equal(2, coverage.Runs)
+	a.True(coverage.Estimated > 2)
+	a.Contains(coverage.String(), "2 random schedules tried out of about")
+}
+
+func TestExplore_ReplayDiverges(t *testing.T) {
+	a := assert.New(t)
+
+	var counter int64
+
+	// A schedule which can not be followed fails instead of running another one
+	_, err := Explore(counterScenario(&counter, true), counterInvariant(&counter), gopactor.ExploreOptions{
+		Replay: []gopactor.Step{{Actor: 7, Message: 0}},
+	})
+	if a.NotNil(err) {
+		a.Contains(err.Error(), "Run 1: The schedule can not be followed at step 1: actor 7 has no message 0 parked")
+	}
+}
+
+func TestExplore_NoSettleTime(t *testing.T) {
+	a := assert.New(t)
+
+	var counter int64
+
+	expected, err := Explore(counterScenario(&counter, false), counterInvariant(&counter))
+	a.Nil(err)
+
+	// Messages among the actors are awaited through their mailboxes,
+	// so the exploration does not depend on timing
+	for i := 0; i < 5; i++ {
+		coverage, err := Explore(counterScenario(&counter, false), counterInvariant(&counter), gopactor.ExploreOptions{
+			Settle: time.Nanosecond,
+		})
+		a.Nil(err)
+		a.Equal(expected, coverage)
+	}
+}
@@ -0,0 +1,217 @@
+// Package fake provides a fake implementation of actor.Context
+// for fast and fully deterministic unit tests of actors.
+//
+// With the fake context, an actor's Receive method is invoked directly
+// on the test goroutine. There is no mailbox, no dispatcher and
+// no background goroutines involved. Instead of being executed,
+// every call the actor makes to the context is recorded, so that
+// the test can examine it afterwards.
+//
+// Example:
+//
+//   ctx := fake.NewContext(&Worker{})
+//   ctx.ReceiveFrom(requestor, "ping")
+//
+//   So(ctx.Responses(), ShouldResemble, []interface{}{"pong"})
+//   So(ctx.CallsOf(fake.MethodSpawnPrefix), ShouldHaveLength, 1)
+//
+// Note that actors stop other actors by calling pid.Stop().
+// This call does not go through the context and cannot be recorded.
+package fake
+
+import (
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+// Method is the name of an actor.Context method.
+type Method string
+
+const (
+	MethodRespond           Method = "Respond"
+	MethodTell              Method = "Tell"
+	MethodRequest           Method = "Request"
+	MethodRequestFuture     Method = "RequestFuture"
+	MethodSpawn             Method = "Spawn"
+	MethodSpawnPrefix       Method = "SpawnPrefix"
+	MethodSpawnNamed        Method = "SpawnNamed"
+	MethodWatch             Method = "Watch"
+	MethodUnwatch           Method = "Unwatch"
+	MethodSetBehavior       Method = "SetBehavior"
+	MethodPushBehavior      Method = "PushBehavior"
+	MethodPopBehavior       Method = "PopBehavior"
+	MethodStash             Method = "Stash"
+	MethodSetReceiveTimeout Method = "SetReceiveTimeout"
+	MethodAwaitFuture       Method = "AwaitFuture"
+)
+
+// Call is a record of a single call to the fake context.
+// Only the fields relevant for the method are set.
+type Call struct {
+	Method Method
+
+	// The actor a message is sent to, the watched actor or the spawned child
+	Target *actor.PID
+
+	// The message being sent, responded or stashed
+	Message interface{}
+
+	// Spawning
+	Props *actor.Props
+	Name  string
+
+	// The future of RequestFuture or AwaitFuture
+	Future *actor.Future
+
+	// The continuation of AwaitFuture. The test may invoke it
+	// when it sees fit, instead of waiting for the future.
+	Continuation func(res interface{}, err error)
+
+	Timeout time.Duration
+}
+
+// Context is a fake actor.Context.
+// It is not meant to be used from more than one goroutine at a time,
+// but it does not break if the actor calls it from its own goroutines.
+type Context struct {
+	mu sync.Mutex
+
+	actor     actor.Actor
+	behaviors []actor.ActorFunc
+
+	self   *actor.PID
+	parent *actor.PID
+
+	// The message currently being handled
+	message interface{}
+	sender  *actor.PID
+
+	children       []*actor.PID
+	stash          []interface{}
+	receiveTimeout time.Duration
+
+	calls []*Call
+}
+
+// NewContext creates a fake context for a given actor.
+func NewContext(a actor.Actor) *Context {
+	ctx := &Context{
+		actor: a,
+		self:  actor.NewLocalPID("fake" + actor.ProcessRegistry.NextId()),
+	}
+	ctx.behaviors = []actor.ActorFunc{a.Receive}
+
+	return ctx
+}
+
+// WithSelf sets the PID the actor sees as its own.
+func (ctx *Context) WithSelf(pid *actor.PID) *Context {
+	ctx.self = pid
+	return ctx
+}
+
+// WithParent sets the PID the actor sees as its parent.
+func (ctx *Context) WithParent(pid *actor.PID) *Context {
+	ctx.parent = pid
+	return ctx
+}
+
+// Receive synchronously delivers a message without a sender to the actor.
+func (ctx *Context) Receive(msg interface{}) {
+	ctx.ReceiveFrom(nil, msg)
+}
+
+// ReceiveFrom synchronously delivers a message from a given sender to the actor.
+func (ctx *Context) ReceiveFrom(sender *actor.PID, msg interface{}) {
+	ctx.mu.Lock()
+	ctx.message = msg
+	ctx.sender = sender
+	behavior := ctx.behaviors[len(ctx.behaviors)-1]
+	ctx.mu.Unlock()
+
+	behavior(ctx)
+
+	ctx.mu.Lock()
+	ctx.message = nil
+	ctx.sender = nil
+	ctx.mu.Unlock()
+}
+
+// Start delivers the Started message to the actor.
+func (ctx *Context) Start() {
+	ctx.Receive(&actor.Started{})
+}
+
+// Calls returns all recorded calls in order.
+func (ctx *Context) Calls() []*Call {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	return append([]*Call(nil), ctx.calls...)
+}
+
+// CallsOf returns all recorded calls of a given method in order.
+func (ctx *Context) CallsOf(method Method) []*Call {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+
+	calls := []*Call{}
+	for _, call := range ctx.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// Responses returns all messages passed to Respond in order.
+func (ctx *Context) Responses() []interface{} {
+	return messagesOf(ctx.CallsOf(MethodRespond))
+}
+
+// Sent returns all messages passed to Tell, Request and RequestFuture in order.
+func (ctx *Context) Sent() []interface{} {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+
+	messages := []interface{}{}
+	for _, call := range ctx.calls {
+		switch call.Method {
+		case MethodTell, MethodRequest, MethodRequestFuture:
+			messages = append(messages, call.Message)
+		}
+	}
+
+	return messages
+}
+
+// Stashed returns all stashed messages in order.
+func (ctx *Context) Stashed() []interface{} {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	return append([]interface{}(nil), ctx.stash...)
+}
+
+// ClearCalls forgets all recorded calls.
+func (ctx *Context) ClearCalls() {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.calls = nil
+}
+
+func (ctx *Context) record(call *Call) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.calls = append(ctx.calls, call)
+}
+
+func messagesOf(calls []*Call) []interface{} {
+	messages := make([]interface{}, 0, len(calls))
+	for _, call := range calls {
+		messages = append(messages, call.Message)
+	}
+
+	return messages
+}
@@ -0,0 +1,146 @@
+package fake_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/fake"
+	"github.com/stretchr/testify/assert"
+)
+
+// An actor that does a bit of everything
+type Manager struct {
+	worker *actor.PID
+}
+
+func (m *Manager) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		m.worker = ctx.SpawnPrefix(actor.FromFunc(func(ctx actor.Context) {}), "worker")
+		ctx.Watch(m.worker)
+		ctx.SetReceiveTimeout(time.Minute)
+	case string:
+		switch msg {
+		case "ping":
+			ctx.Respond("pong")
+		case "delegate":
+			ctx.Request(m.worker, "job")
+		case "later":
+			ctx.Stash()
+		case "sleep":
+			ctx.SetBehavior(m.Sleeping)
+		}
+	}
+}
+
+func (m *Manager) Sleeping(ctx actor.Context) {
+	if msg, ok := ctx.Message().(string); ok && msg == "wake up" {
+		ctx.SetBehavior(m.Receive)
+		return
+	}
+	ctx.Respond("zzz")
+}
+
+func TestContext_Start(t *testing.T) {
+	a := assert.New(t)
+
+	ctx := fake.NewContext(&Manager{})
+	ctx.Start()
+
+	calls := ctx.Calls()
+	a.Len(calls, 3)
+
+	a.Equal(fake.MethodSpawnPrefix, calls[0].Method)
+	a.Equal("worker", calls[0].Name)
+	a.Contains(calls[0].Target.String(), "worker")
+	a.Equal(ctx.Children(), []*actor.PID{calls[0].Target})
+
+	a.Equal(fake.MethodWatch, calls[1].Method)
+	a.Equal(calls[0].Target, calls[1].Target)
+
+	a.Equal(fake.MethodSetReceiveTimeout, calls[2].Method)
+	a.Equal(time.Minute, ctx.ReceiveTimeout())
+}
+
+func TestContext_Messaging(t *testing.T) {
+	a := assert.New(t)
+
+	requestor := actor.NewLocalPID("requestor")
+	manager := &Manager{}
+	ctx := fake.NewContext(manager)
+	ctx.Start()
+	ctx.ClearCalls()
+
+	// Respond
+	ctx.ReceiveFrom(requestor, "ping")
+	a.Equal([]interface{}{"pong"}, ctx.Responses())
+	a.Equal(requestor, ctx.CallsOf(fake.MethodRespond)[0].Target)
+
+	// Request
+	ctx.Receive("delegate")
+	a.Equal([]interface{}{"job"}, ctx.Sent())
+	a.Equal(manager.worker, ctx.CallsOf(fake.MethodRequest)[0].Target)
+
+	// Stash
+	ctx.Receive("later")
+	a.Equal([]interface{}{"later"}, ctx.Stashed())
+
+	// Nothing is left over after a message is handled
+	a.Nil(ctx.Message())
+	a.Nil(ctx.Sender())
+}
+
+func TestContext_Behavior(t *testing.T) {
+	a := assert.New(t)
+
+	ctx := fake.NewContext(&Manager{})
+
+	ctx.Receive("sleep")
+	a.Len(ctx.CallsOf(fake.MethodSetBehavior), 1)
+
+	ctx.Receive("ping")
+	a.Equal([]interface{}{"zzz"}, ctx.Responses())
+
+	ctx.Receive("wake up")
+	ctx.Receive("ping")
+	a.Equal([]interface{}{"zzz", "pong"}, ctx.Responses())
+}
+
+func TestContext_SpawnNamed(t *testing.T) {
+	a := assert.New(t)
+
+	ctx := fake.NewContext(&Manager{}).WithSelf(actor.NewLocalPID("manager"))
+	props := actor.FromFunc(func(ctx actor.Context) {})
+
+	child, err := ctx.SpawnNamed(props, "child")
+	a.Nil(err)
+	a.Equal("manager/child", child.Id)
+
+	_, err = ctx.SpawnNamed(props, "child")
+	a.Equal(actor.ErrNameExists, err)
+	a.Len(ctx.CallsOf(fake.MethodSpawnNamed), 1)
+}
+
+func TestContext_AwaitFuture(t *testing.T) {
+	a := assert.New(t)
+
+	var result interface{}
+	ctx := fake.NewContext(actor.ActorFunc(func(ctx actor.Context) {
+		f := ctx.RequestFuture(actor.NewLocalPID("somebody"), "question", time.Second)
+		ctx.AwaitFuture(f, func(res interface{}, err error) {
+			result = res
+		})
+	}))
+
+	ctx.Receive("go")
+
+	calls := ctx.CallsOf(fake.MethodAwaitFuture)
+	a.Len(calls, 1)
+	a.Equal(ctx.CallsOf(fake.MethodRequestFuture)[0].Future, calls[0].Future)
+
+	// The test decides when the continuation runs
+	a.Nil(result)
+	calls[0].Continuation(42, nil)
+	a.Equal(42, result)
+}
@@ -0,0 +1,190 @@
+package fake
+
+import (
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+)
+
+// The fake context should be a drop-in replacement for the real one
+var _ actor.Context = (*Context)(nil)
+
+// Header is a fake message header. Messages delivered
+// to the fake context never have any headers.
+type Header map[string]string
+
+func (h Header) Get(key string) string { return h[key] }
+func (h Header) Length() int           { return len(h) }
+
+func (h Header) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for key := range h {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (h Header) ToMap() map[string]string {
+	m := make(map[string]string, len(h))
+	for key, value := range h {
+		m[key] = value
+	}
+	return m
+}
+
+func (ctx *Context) Message() interface{} {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	return ctx.message
+}
+
+func (ctx *Context) Sender() *actor.PID {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	return ctx.sender
+}
+
+func (ctx *Context) MessageHeader() actor.ReadonlyMessageHeader {
+	return Header{}
+}
+
+func (ctx *Context) Self() *actor.PID   { return ctx.self }
+func (ctx *Context) Parent() *actor.PID { return ctx.parent }
+func (ctx *Context) Actor() actor.Actor { return ctx.actor }
+
+func (ctx *Context) Children() []*actor.PID {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	return append([]*actor.PID(nil), ctx.children...)
+}
+
+func (ctx *Context) Respond(response interface{}) {
+	ctx.record(&Call{Method: MethodRespond, Target: ctx.Sender(), Message: response})
+}
+
+func (ctx *Context) Tell(pid *actor.PID, message interface{}) {
+	ctx.record(&Call{Method: MethodTell, Target: pid, Message: message})
+}
+
+func (ctx *Context) Request(pid *actor.PID, message interface{}) {
+	ctx.record(&Call{Method: MethodRequest, Target: pid, Message: message})
+}
+
+// RequestFuture records the call and returns a real future.
+// The test can complete it by sending a message to its PID.
+func (ctx *Context) RequestFuture(pid *actor.PID, message interface{}, timeout time.Duration) *actor.Future {
+	future := actor.NewFuture(timeout)
+	ctx.record(&Call{
+		Method:  MethodRequestFuture,
+		Target:  pid,
+		Message: message,
+		Future:  future,
+		Timeout: timeout,
+	})
+
+	return future
+}
+
+// AwaitFuture records the call. The continuation is never invoked
+// by the fake context itself: the test can invoke it via the recorded call.
+func (ctx *Context) AwaitFuture(f *actor.Future, continuation func(res interface{}, err error)) {
+	ctx.record(&Call{Method: MethodAwaitFuture, Future: f, Continuation: continuation})
+}
+
+func (ctx *Context) Watch(pid *actor.PID) {
+	ctx.record(&Call{Method: MethodWatch, Target: pid})
+}
+
+func (ctx *Context) Unwatch(pid *actor.PID) {
+	ctx.record(&Call{Method: MethodUnwatch, Target: pid})
+}
+
+func (ctx *Context) SetReceiveTimeout(d time.Duration) {
+	ctx.mu.Lock()
+	ctx.receiveTimeout = d
+	ctx.mu.Unlock()
+
+	ctx.record(&Call{Method: MethodSetReceiveTimeout, Timeout: d})
+}
+
+func (ctx *Context) ReceiveTimeout() time.Duration {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	return ctx.receiveTimeout
+}
+
+// SetBehavior records the call and actually replaces the behavior,
+// so the next delivered message is handled by the new one.
+func (ctx *Context) SetBehavior(behavior actor.ActorFunc) {
+	ctx.mu.Lock()
+	ctx.behaviors = []actor.ActorFunc{behavior}
+	ctx.mu.Unlock()
+
+	ctx.record(&Call{Method: MethodSetBehavior})
+}
+
+func (ctx *Context) PushBehavior(behavior actor.ActorFunc) {
+	ctx.mu.Lock()
+	ctx.behaviors = append(ctx.behaviors, behavior)
+	ctx.mu.Unlock()
+
+	ctx.record(&Call{Method: MethodPushBehavior})
+}
+
+func (ctx *Context) PopBehavior() {
+	ctx.mu.Lock()
+	if len(ctx.behaviors) > 1 {
+		ctx.behaviors = ctx.behaviors[:len(ctx.behaviors)-1]
+	}
+	ctx.mu.Unlock()
+
+	ctx.record(&Call{Method: MethodPopBehavior})
+}
+
+func (ctx *Context) Stash() {
+	ctx.mu.Lock()
+	message := ctx.message
+	ctx.stash = append(ctx.stash, message)
+	ctx.mu.Unlock()
+
+	ctx.record(&Call{Method: MethodStash, Message: message})
+}
+
+// Spawn does not start anything. It records the call
+// and returns a PID of a child that does not exist.
+func (ctx *Context) Spawn(props *actor.Props) *actor.PID {
+	pid := ctx.addChild(actor.ProcessRegistry.NextId())
+	ctx.record(&Call{Method: MethodSpawn, Target: pid, Props: props})
+
+	return pid
+}
+
+func (ctx *Context) SpawnPrefix(props *actor.Props, prefix string) *actor.PID {
+	pid := ctx.addChild(prefix + actor.ProcessRegistry.NextId())
+	ctx.record(&Call{Method: MethodSpawnPrefix, Target: pid, Props: props, Name: prefix})
+
+	return pid
+}
+
+func (ctx *Context) SpawnNamed(props *actor.Props, id string) (*actor.PID, error) {
+	for _, child := range ctx.Children() {
+		if child.Id == ctx.self.Id+"/"+id {
+			return child, actor.ErrNameExists
+		}
+	}
+
+	pid := ctx.addChild(id)
+	ctx.record(&Call{Method: MethodSpawnNamed, Target: pid, Props: props, Name: id})
+
+	return pid, nil
+}
+
+func (ctx *Context) addChild(name string) *actor.PID {
+	pid := actor.NewLocalPID(ctx.self.Id + "/" + name)
+
+	ctx.mu.Lock()
+	ctx.children = append(ctx.children, pid)
+	ctx.mu.Unlock()
+
+	return pid
+}
@@ -0,0 +1,180 @@
+package gopactor
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/options"
+	"github.com/stretchr/testify/assert"
+)
+
+type ack struct{ N int }
+
+func TestFaults_Outbound(t *testing.T) {
+	a := assert.New(t)
+
+	receiver, _ := SpawnNullActor(OptInboundInterceptionOnly.WithJournaling().WithTimeout(50 * time.Millisecond))
+	sender, _ := SpawnFromFunc(func(ctx actor.Context) {
+		if n, ok := ctx.Message().(int); ok {
+			ctx.Tell(receiver, &ack{N: n})
+		}
+	}, OptNoInterception.WithOutboundFault(options.Fault{
+		Action: options.FaultDrop,
+		Every:  2,
+		Filter: options.Filter{MessageType: reflect.TypeOf(&ack{}), Target: receiver},
+	}))
+
+	// Every second ack is lost
+	for i := 1; i <= 4; i++ {
+		sender.Tell(i)
+	}
+	a.Empty(ShouldReceive(receiver, &ack{N: 1}))
+	a.Empty(ShouldReceive(receiver, &ack{N: 3}))
+	a.Empty(ShouldNotReceive(receiver))
+
+	// Cleanup
+	PactReset()
+}
+
+func TestFaults_Inbound(t *testing.T) {
+	a := assert.New(t)
+
+	received := make(chan interface{}, 10)
+	opt := OptDefault.WithJournaling().WithTimeout(time.Second).
+		WithInboundFault(options.Fault{Action: options.FaultDuplicate, Filter: options.Filter{MessageType: reflect.TypeOf(&ack{})}}).
+		WithInboundFault(options.Fault{Action: options.FaultReorder, Filter: options.Filter{MessageType: reflect.TypeOf(0)}}).
+		WithInboundFault(options.Fault{Action: options.FaultDelay, Delay: 20 * time.Millisecond, Filter: options.Filter{Predicate: func(msg interface{}) bool { return msg == "late" }}})
+	receiver, _ := SpawnFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*actor.Started); !ok {
+			received <- ctx.Message()
+		}
+	}, opt)
+
+	receiver.Tell("late")
+	receiver.Tell(1)
+	receiver.Tell(2)
+	receiver.Tell(&ack{N: 1})
+
+	// Only delivered messages are intercepted
+	a.Empty(ShouldReceive(receiver, 2))
+	a.Empty(ShouldReceive(receiver, 1))
+	a.Empty(ShouldReceive(receiver, &ack{N: 1}))
+	a.Empty(ShouldReceive(receiver, &ack{N: 1}))
+	a.Empty(ShouldReceive(receiver, "late"))
+
+	a.Equal([]interface{}{2, 1, &ack{N: 1}, &ack{N: 1}, "late"}, []interface{}{<-received, <-received, <-received, <-received, <-received})
+
+	// Cleanup
+	PactReset()
+}
+
+func TestFaults_OutboundDelay(t *testing.T) {
+	a := assert.New(t)
+
+	receiver, _ := SpawnNullActor(OptInboundInterceptionOnly.WithJournaling().WithTimeout(time.Second))
+	sender, _ := SpawnFromFunc(func(ctx actor.Context) {
+		if n, ok := ctx.Message().(int); ok {
+			ctx.Request(receiver, &ack{N: n})
+		}
+	}, OptNoInterception.WithOutboundFault(options.Fault{
+		Action: options.FaultDelay,
+		Delay:  10 * time.Millisecond,
+		Filter: options.Filter{MessageType: reflect.TypeOf(&ack{})},
+	}))
+
+	// The delayed request is sent on behalf of the actor
+	sender.Tell(1)
+	a.Empty(ShouldReceiveFrom(receiver, sender, &ack{N: 1}))
+
+	// Cleanup
+	PactReset()
+}
+
+func TestFaults_RuleOrder(t *testing.T) {
+	a := assert.New(t)
+
+	filter := options.Filter{MessageType: reflect.TypeOf(&ack{})}
+	receiver, _ := SpawnNullActor(OptInboundInterceptionOnly.WithJournaling().WithTimeout(50 * time.Millisecond))
+	sender, _ := SpawnFromFunc(func(ctx actor.Context) {
+		if n, ok := ctx.Message().(int); ok {
+			ctx.Tell(receiver, &ack{N: n})
+		}
+	}, OptNoInterception.
+		WithOutboundFault(options.Fault{Action: options.FaultDrop, Every: 3, Filter: filter}).
+		WithOutboundFault(options.Fault{Action: options.FaultDuplicate, Filter: filter}))
+
+	// Acks skipped by the first rule are left to the second one
+	for i := 1; i <= 3; i++ {
+		sender.Tell(i)
+	}
+	a.Empty(ShouldReceive(receiver, &ack{N: 1}))
+	a.Empty(ShouldReceive(receiver, &ack{N: 1}))
+	a.Empty(ShouldReceive(receiver, &ack{N: 2}))
+	a.Empty(ShouldReceive(receiver, &ack{N: 2}))
+	a.Empty(ShouldNotReceive(receiver))
+
+	// Cleanup
+	PactReset()
+}
+
+func TestFaults_HeldWithoutNext(t *testing.T) {
+	a := assert.New(t)
+
+	logged := &syncBuffer{}
+	log.SetOutput(logged)
+	defer log.SetOutput(os.Stderr)
+
+	reorder := options.Fault{Action: options.FaultReorder, Filter: options.Filter{MessageType: reflect.TypeOf(&ack{})}}
+	receiver, _ := SpawnNullActor(OptInboundInterceptionOnly.WithJournaling().WithTimeout(50 * time.Millisecond).WithInboundFault(reorder))
+	sender, _ := SpawnFromFunc(func(ctx actor.Context) {
+		if n, ok := ctx.Message().(int); ok {
+			ctx.Tell(receiver, &ack{N: n})
+		}
+	}, OptNoInterception.WithOutboundFault(reorder).WithSystemInterception().WithTimeout(time.Second))
+
+	// The only ack sent is released when the sender stops
+	a.Empty(ShouldStart(sender))
+	sender.Tell(1)
+	a.Empty(ShouldNotReceive(receiver))
+	sender.Stop()
+	a.Empty(ShouldStop(sender))
+
+	// Then it is held by the receiver, and dropped when it stops
+	a.Empty(ShouldNotReceive(receiver))
+	receiver.Stop()
+	deadline := time.Now().Add(time.Second)
+	for !strings.Contains(logged.String(), "dropped when the actor stopped") && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	a.Contains(logged.String(), "outbound message &gopactor.ack{N:1} from "+sender.String()+" to "+receiver.String()+" released when the actor stopped")
+	a.Contains(logged.String(), "inbound message &gopactor.ack{N:1} from <nil> to "+receiver.String()+" dropped when the actor stopped")
+
+	// Cleanup
+	PactReset()
+}
+
+// Log output written by actors and read by the test
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
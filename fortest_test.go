@@ -0,0 +1,172 @@
+package gopactor
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/gopactor"
+	"github.com/stretchr/testify/assert"
+)
+
+// Records cleanup functions and failures instead of running and failing the test
+type fakeT struct {
+	cleanups []func()
+	errors   []string
+	logs     []string
+	failed   bool
+}
+
+func (t *fakeT) Cleanup(f func()) {
+	t.cleanups = append(t.cleanups, f)
+}
+
+func (t *fakeT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+	t.failed = true
+}
+
+func (t *fakeT) Logf(format string, args ...interface{}) {
+	t.logs = append(t.logs, fmt.Sprintf(format, args...))
+}
+
+func (t *fakeT) Failed() bool {
+	return t.failed
+}
+
+func (t *fakeT) Name() string {
+	return "TestFake/with spaces"
+}
+
+func TestForTest(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		i := i
+		t.Run(fmt.Sprintf("worker-%d", i), func(t *testing.T) {
+			t.Parallel()
+			a := assert.New(t)
+			p := ForTest(t)
+
+			worker, _ := p.SpawnFromFunc(func(ctx actor.Context) {
+				if msg, ok := ctx.Message().(int); ok {
+					ctx.Respond(msg * 2)
+				}
+			}, OptDefault.WithSystemInterception().WithTimeout(time.Second))
+
+			a.Nil(p.Expect(worker).ToStart().Verify())
+
+			worker.Tell(i)
+			a.Nil(p.Expect(worker).ToReceive(i).Verify())
+
+			// The response is never consumed, so the worker stays blocked
+			// until the cleanup unblocks it.
+			worker.Tell(i)
+
+			// The worker is not visible to other instances
+			a.Contains(ShouldReceive(worker, i), "not registered")
+		})
+	}
+}
+
+func TestForTest_Cleanup(t *testing.T) {
+	a := assert.New(t)
+
+	ft := &fakeT{}
+	p := ForTest(ft)
+	a.Len(ft.cleanups, 1)
+
+	// Actors blocked by the interception are stopped
+	worker, _ := p.SpawnFromFunc(func(ctx actor.Context) {}, OptDefault.WithRealSpawning().WithRecursiveInterception(1))
+	worker.Tell("blocked")
+
+	ft.cleanups[0]()
+	a.Empty(ft.errors)
+	a.Contains(p.ShouldReceive(worker, "blocked"), "not registered")
+}
+
+func TestForTest_StuckActor(t *testing.T) {
+	a := assert.New(t)
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+
+	p := gopactor.New()
+	stuck, _ := p.SpawnFromFunc(func(ctx actor.Context) {
+		if ctx.Message() == "block" {
+			close(entered)
+			<-release
+		}
+	}, OptNoInterception)
+	stuck.Tell("block")
+	<-entered
+
+	err := p.Close(20 * time.Millisecond)
+	if a.NotNil(err) {
+		a.Contains(err.Error(), "1 of 1 actors did not stop")
+	}
+}
+
+func TestForTest_LeakedGoroutine(t *testing.T) {
+	a := assert.New(t)
+
+	release := make(chan struct{})
+	defer close(release)
+
+	ft := &fakeT{}
+	p := ForTest(ft)
+
+	started := make(chan struct{})
+	leaky, _ := p.SpawnFromFunc(func(ctx actor.Context) {
+		if ctx.Message() == "start" {
+			go func() {
+				close(started)
+				<-release
+			}()
+		}
+	}, OptNoInterception)
+	leaky.Tell("start")
+	<-started
+
+	ft.cleanups[0]()
+	if a.Len(ft.errors, 1) {
+		a.Contains(ft.errors[0], "1 goroutines are left behind")
+		a.Contains(ft.errors[0], "TestForTest_LeakedGoroutine")
+	}
+}
+
+func TestForTest_OtherGoroutines(t *testing.T) {
+	a := assert.New(t)
+
+	release := make(chan struct{})
+	defer close(release)
+
+	ft := &fakeT{}
+	p := ForTest(ft)
+
+	// A goroutine of the test itself, or of another test, is not counted
+	go func() {
+		<-release
+	}()
+
+	// But goroutines started by the actor and by its goroutines are
+	started := make(chan struct{})
+	leaky, _ := p.SpawnFromFunc(func(ctx actor.Context) {
+		if ctx.Message() == "start" {
+			go func() {
+				go func() {
+					close(started)
+					<-release
+				}()
+				<-release
+			}()
+		}
+	}, OptNoInterception)
+	leaky.Tell("start")
+	<-started
+
+	ft.cleanups[0]()
+	if a.Len(ft.errors, 1) {
+		a.Contains(ft.errors[0], "2 goroutines are left behind")
+	}
+}
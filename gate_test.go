@@ -0,0 +1,64 @@
+package gopactor
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGating(t *testing.T) {
+	a := assert.New(t)
+
+	handled := make(chan string, 10)
+	opt := OptDefault.WithGating().WithJournaling().WithTimeout(100 * time.Millisecond)
+	spawn := func(name string) *actor.PID {
+		pid, _ := SpawnFromFunc(func(ctx actor.Context) {
+			if msg, ok := ctx.Message().(string); ok {
+				handled <- name + ":" + msg
+			}
+		}, opt)
+		return pid
+	}
+	first, second := spawn("first"), spawn("second")
+
+	// The test decides the interleaving
+	first.Tell("go")
+	second.Tell("go")
+	a.Nil(Release(second))
+	a.Equal("second:go", <-handled)
+	a.Nil(Release(first))
+	a.Equal("first:go", <-handled)
+
+	// A dropped message is never delivered, nor intercepted
+	first.Tell("lost")
+	a.Nil(Drop(first))
+	a.Empty(ShouldReceive(first, "go"))
+	a.Empty(ShouldNotReceive(first))
+
+	// A replaced message is delivered instead
+	first.Tell("original")
+	a.Nil(Replace(first, "replaced"))
+	a.Equal("first:replaced", <-handled)
+	a.Empty(ShouldReceive(first, "replaced"))
+
+	// Everything parked right now
+	first.Tell("again")
+	second.Tell("again")
+	deadline := time.Now().Add(time.Second)
+	for released := 0; released < 2 && time.Now().Before(deadline); {
+		released += ReleaseAll()
+	}
+	both := []string{<-handled, <-handled}
+	sort.Strings(both)
+	a.Equal([]string{"first:again", "second:again"}, both)
+
+	// Nothing is parked
+	a.Contains(Release(spawn("idle")).Error(), "while waiting for a parked message")
+	a.Equal("Object is not an actor PID", Release(nil).Error())
+
+	// Cleanup
+	PactReset()
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/AsynkronIT/protoactor-go/actor"
 	"github.com/meamidos/gopactor/gopactor"
 	"github.com/meamidos/gopactor/options"
+	"github.com/meamidos/gopactor/trace"
 )
 
 // Analog of Protoactor's actor.SpawnPrefix(actor.FromInstance(...))
@@ -30,6 +31,29 @@ func SpawnNullActor(opts ...options.Options) (*actor.PID, error) {
 	return gopactor.DEFAULT_GOPACTOR.SpawnNullActor(opts...)
 }
 
+// Expect starts a typed expectation about an actor.
+// Unlike goconvey-style assertions, it returns an error:
+//   err := Expect(worker).ToReceive("ping").From(requestor).Within(time.Second)
+func Expect(pid *actor.PID) *gopactor.Expectation {
+	return gopactor.DEFAULT_GOPACTOR.Expect(pid)
+}
+
+// NewProbe spawns a probe: an actor which records every message it receives,
+// so that the test can await them one by one.
+func NewProbe(opts ...options.Options) (*gopactor.Probe, error) {
+	return gopactor.DEFAULT_GOPACTOR.NewProbe(opts...)
+}
+
+// ForTest creates an isolated Gopactor instance for a single test.
+// When the test finishes, the instance stops all actors it has spawned.
+// It is safe to use in parallel subtests:
+//   p := ForTest(t)
+//   worker, _ := p.SpawnFromInstance(&Worker{})
+//   err := p.Expect(worker).ToReceive("ping").Verify()
+func ForTest(t gopactor.TestingT) *gopactor.Gopactor {
+	return gopactor.ForTest(t)
+}
+
 // PactReset cleans up internal data structures used by Gopactor.
 // Normally, you do not have to use it. If you just test a dozen of actors
 // in a short-living test, there is no need to care about cleaning up.
@@ -38,3 +62,51 @@ func SpawnNullActor(opts ...options.Options) (*actor.PID, error) {
 func PactReset() {
 	gopactor.DEFAULT_GOPACTOR.Reset()
 }
+
+// Release lets through the next message parked by an actor
+// spawned with gating. It waits up to the timeout from the options:
+//   worker, _ := SpawnFromInstance(&Worker{}, OptDefault.WithGating())
+//   worker.Tell("ping")
+//   err := Release(worker)
+func Release(pid *actor.PID) error {
+	return gopactor.DEFAULT_GOPACTOR.Release(pid)
+}
+
+// Drop discards the next message parked by an actor spawned with gating.
+func Drop(pid *actor.PID) error {
+	return gopactor.DEFAULT_GOPACTOR.Drop(pid)
+}
+
+// Replace delivers another message instead of the next one parked by
+// an actor spawned with gating.
+func Replace(pid *actor.PID, msg interface{}) error {
+	return gopactor.DEFAULT_GOPACTOR.Replace(pid, msg)
+}
+
+// ReleaseAll lets through the messages parked by all gated actors right now,
+// and returns their number.
+func ReleaseAll() int {
+	return gopactor.DEFAULT_GOPACTOR.ReleaseAll()
+}
+
+// Explore runs a scenario many times with different delivery orders
+// of the messages pending at gated actors, and checks the invariant after every run.
+// A failed run is reported as a *gopactor.Counterexample with the seed and the schedule.
+// The coverage tells whether all schedules have been tried, or only a sample:
+//   coverage, err := Explore(func(p *gopactor.Gopactor) {
+//       account, _ := p.SpawnFromInstance(&Account{}, OptNoInterception.WithGating())
+//       // ... spawn clients and send the first messages ...
+//   }, func(p *gopactor.Gopactor) error {
+//       // ... check the outcome ...
+//   })
+func Explore(scenario gopactor.Scenario, invariant gopactor.Invariant, opts ...gopactor.ExploreOptions) (gopactor.Coverage, error) {
+	return gopactor.Explore(scenario, invariant, opts...)
+}
+
+// Trace returns the conversation among all actors spawned by Gopactor, in order.
+// It can be rendered as a sequence diagram:
+//   fmt.Println(Trace().PlantUML())
+//   fmt.Println(Trace().Mermaid())
+func Trace() *trace.Trace {
+	return gopactor.DEFAULT_GOPACTOR.Trace()
+}
@@ -2,8 +2,10 @@ package gopactor
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/catcher"
 )
 
 // ShouldReceive is an assertion method. Its rules are:
@@ -21,7 +23,7 @@ func (p *Gopactor) ShouldReceive(param1 interface{}, params ...interface{}) stri
 
 	expectedMsg := params[0]
 
-	return p.shouldReceive(receiver, nil, expectedMsg)
+	return catcher.FailureMessage(p.Expect(receiver).ToReceive(expectedMsg).Verify())
 }
 
 // ShouldReceiveFrom is an assertion method. Its rules are:
@@ -44,7 +46,69 @@ func (p *Gopactor) ShouldReceiveFrom(param1 interface{}, params ...interface{})
 
 	expectedMsg := params[1]
 
-	return p.shouldReceive(receiver, sender, expectedMsg)
+	return catcher.FailureMessage(p.Expect(receiver).ToReceive(expectedMsg).From(sender).Verify())
+}
+
+// ShouldReceiveAll is an assertion method. Its rules are:
+// - The receiver should receive all given messages in any order.
+// - Other messages are allowed.
+func (p *Gopactor) ShouldReceiveAll(param1 interface{}, params ...interface{}) string {
+	receiver, ok := param1.(*actor.PID)
+	if !ok {
+		return "Receiver is not an actor PID"
+	}
+
+	if len(params) == 0 {
+		return "At least one expected message is required"
+	}
+
+	return catcher.FailureMessage(p.Expect(receiver).ToReceiveAll(params...).Verify())
+}
+
+// ShouldEventuallyReceive is an assertion method. Its rules are:
+// - The receiver should receive a given message before the timeout expires.
+// - Other messages received in the meantime are discarded.
+// - Optional parameters: a custom timeout (time.Duration) and KeepSkipped
+//   to keep other messages for the following assertions.
+func (p *Gopactor) ShouldEventuallyReceive(param1 interface{}, params ...interface{}) string {
+	receiver, ok := param1.(*actor.PID)
+	if !ok {
+		return "Receiver is not an actor PID"
+	}
+
+	if len(params) < 1 {
+		return "One parameter with a message is required to assert receiving"
+	}
+
+	e := p.Expect(receiver).ToEventuallyReceive(params[0])
+	return verifyWithModifiers(e, params[1:])
+}
+
+// ShouldConsistentlyNotReceive is an assertion method. Its rules are:
+// - The receiver should not receive a given message during the whole window.
+// - If no message is given, the receiver should not receive anything at all.
+// - Other messages are discarded.
+// - Optional parameters: the window (time.Duration), the timeout by default.
+func (p *Gopactor) ShouldConsistentlyNotReceive(param1 interface{}, params ...interface{}) string {
+	receiver, ok := param1.(*actor.PID)
+	if !ok {
+		return "Receiver is not an actor PID"
+	}
+
+	var forbiddenMsg interface{}
+	if len(params) > 0 {
+		forbiddenMsg = params[0]
+	}
+
+	var window time.Duration
+	if len(params) > 1 {
+		window, ok = params[1].(time.Duration)
+		if !ok || len(params) > 2 {
+			return "Only a message and a window (time.Duration) are allowed"
+		}
+	}
+
+	return catcher.FailureMessage(p.Expect(receiver).NotToReceive(forbiddenMsg).Within(window))
 }
 
 // ShouldReceiveSomething is an assertion method. Its rules are:
@@ -56,7 +120,7 @@ func (p *Gopactor) ShouldReceiveSomething(param1 interface{}, _ ...interface{})
 		return "Receiver is not an actor PID"
 	}
 
-	return p.shouldReceive(receiver, nil, nil)
+	return catcher.FailureMessage(p.Expect(receiver).ToReceive(nil).Verify())
 }
 
 // ShouldReceiveN is an assertion method. Its rules are:
@@ -78,8 +142,8 @@ func (p *Gopactor) ShouldReceiveN(param1 interface{}, params ...interface{}) str
 	}
 
 	for i := 0; i < expectedMessages; i++ {
-		res := p.shouldReceive(receiver, nil, nil)
-		if res != "" {
+		err := p.Expect(receiver).ToReceive(nil).Verify()
+		if err != nil {
 			return fmt.Sprintf("Expected %d messages, but got %d", expectedMessages, i)
 		}
 	}
@@ -95,7 +159,7 @@ func (p *Gopactor) ShouldStart(param1 interface{}, _ ...interface{}) string {
 		return "Object is not an actor PID"
 	}
 
-	return p.shouldStart(pid)
+	return catcher.FailureMessage(p.Expect(pid).ToStart().Verify())
 }
 
 // ShouldStop is an assertion method. Its rules are:
@@ -106,7 +170,112 @@ func (p *Gopactor) ShouldStop(param1 interface{}, _ ...interface{}) string {
 		return "Object is not an actor PID"
 	}
 
-	return p.shouldStop(pid)
+	return catcher.FailureMessage(p.Expect(pid).ToStop().Verify())
+}
+
+// ShouldBeStopping is an assertion method. Its rules are:
+// - The actor should receive a system message that indicates the actor is about to stop.
+func (p *Gopactor) ShouldBeStopping(param1 interface{}, _ ...interface{}) string {
+	pid, ok := param1.(*actor.PID)
+	if !ok {
+		return "Object is not an actor PID"
+	}
+
+	return catcher.FailureMessage(p.Expect(pid).ToBeStopping().Verify())
+}
+
+// ShouldReceiveRestart is an assertion method. Its rules are:
+// - The actor should be told to restart by its supervisor.
+func (p *Gopactor) ShouldReceiveRestart(param1 interface{}, _ ...interface{}) string {
+	pid, ok := param1.(*actor.PID)
+	if !ok {
+		return "Object is not an actor PID"
+	}
+
+	return catcher.FailureMessage(p.Expect(pid).ToReceiveRestart().Verify())
+}
+
+// ShouldReceiveTimeout is an assertion method. Its rules are:
+// - The receive timeout of the actor should expire.
+func (p *Gopactor) ShouldReceiveTimeout(param1 interface{}, _ ...interface{}) string {
+	pid, ok := param1.(*actor.PID)
+	if !ok {
+		return "Object is not an actor PID"
+	}
+
+	return catcher.FailureMessage(p.Expect(pid).ToReceiveTimeout().Verify())
+}
+
+// ShouldBePoisoned is an assertion method. Its rules are:
+// - The actor should receive a PoisonPill.
+func (p *Gopactor) ShouldBePoisoned(param1 interface{}, _ ...interface{}) string {
+	pid, ok := param1.(*actor.PID)
+	if !ok {
+		return "Object is not an actor PID"
+	}
+
+	return catcher.FailureMessage(p.Expect(pid).ToBePoisoned().Verify())
+}
+
+// ShouldBeWatched is an assertion method. Its rules are:
+// - Another actor should start watching the actor.
+// - If no PID is given, any watcher will suffice.
+// - The watcher should be spawned by Gopactor.
+func (p *Gopactor) ShouldBeWatched(param1 interface{}, params ...interface{}) string {
+	return p.shouldObserve(param1, params, (*Expectation).ToBeWatched)
+}
+
+// ShouldBeUnwatched is an assertion method. Its rules are:
+// - Another actor should stop watching the actor.
+// - If no PID is given, any watcher will suffice.
+// - The watcher should be spawned by Gopactor.
+func (p *Gopactor) ShouldBeUnwatched(param1 interface{}, params ...interface{}) string {
+	return p.shouldObserve(param1, params, (*Expectation).ToBeUnwatched)
+}
+
+// ShouldObserveFailure is an assertion method. Its rules are:
+// - A child of the actor should fail.
+// - If no PID is given, any child will suffice.
+func (p *Gopactor) ShouldObserveFailure(param1 interface{}, params ...interface{}) string {
+	return p.shouldObserve(param1, params, (*Expectation).ToObserveFailure)
+}
+
+// ShouldReceiveSystem is an assertion method. Its rules are:
+// - The actor should receive a given system message.
+// - The message can be a matcher.
+// - Other system messages received in the meantime are skipped.
+func (p *Gopactor) ShouldReceiveSystem(param1 interface{}, params ...interface{}) string {
+	pid, ok := param1.(*actor.PID)
+	if !ok {
+		return "Object is not an actor PID"
+	}
+
+	if len(params) != 1 {
+		return "One parameter with a system message or a matcher is required"
+	}
+
+	return catcher.FailureMessage(p.Expect(pid).ToReceiveSysMsg(params[0]).Verify())
+}
+
+func (p *Gopactor) shouldObserve(param1 interface{}, params []interface{}, expect func(*Expectation, *actor.PID) *Expectation) string {
+	object, ok := param1.(*actor.PID)
+	if !ok {
+		return "Object is not an actor PID"
+	}
+
+	if len(params) > 1 {
+		return "At most one parameter with an actor PID is allowed"
+	}
+
+	var pid *actor.PID
+	if len(params) == 1 {
+		pid, ok = params[0].(*actor.PID)
+		if !ok {
+			return "Parameter should be an actor PID"
+		}
+	}
+
+	return catcher.FailureMessage(expect(p.Expect(object), pid).Verify())
 }
 
 // ShouldBeRestarting is an assertion method. Its rules are:
@@ -117,7 +286,46 @@ func (p *Gopactor) ShouldBeRestarting(param1 interface{}, _ ...interface{}) stri
 		return "Object is not an actor PID"
 	}
 
-	return p.shouldBeRestarting(pid)
+	return catcher.FailureMessage(p.Expect(pid).ToBeRestarting().Verify())
+}
+
+// ShouldFail is an assertion method. Its rules are:
+// - The actor should panic while handling a message.
+// - It does not matter what the actor panics with.
+func (p *Gopactor) ShouldFail(param1 interface{}, _ ...interface{}) string {
+	pid, ok := param1.(*actor.PID)
+	if !ok {
+		return "Object is not an actor PID"
+	}
+
+	return catcher.FailureMessage(p.Expect(pid).ToFail(nil).Verify())
+}
+
+// ShouldFailWith is an assertion method. Its rules are:
+// - The actor should panic while handling a message.
+// - The reason of the panic should match a given value or a matcher.
+func (p *Gopactor) ShouldFailWith(param1 interface{}, params ...interface{}) string {
+	pid, ok := param1.(*actor.PID)
+	if !ok {
+		return "Object is not an actor PID"
+	}
+
+	if len(params) != 1 || params[0] == nil {
+		return "One parameter with a reason is required to assert a failure"
+	}
+
+	return catcher.FailureMessage(p.Expect(pid).ToFail(params[0]).Verify())
+}
+
+// ShouldNotFail is an assertion method. Its rules are:
+// - The actor should not panic within the timeout.
+func (p *Gopactor) ShouldNotFail(param1 interface{}, _ ...interface{}) string {
+	pid, ok := param1.(*actor.PID)
+	if !ok {
+		return "Object is not an actor PID"
+	}
+
+	return catcher.FailureMessage(p.Expect(pid).NotToFail().Verify())
 }
 
 // ShouldObserveTermination is an assertion method. Its rules are:
@@ -137,7 +345,58 @@ func (p *Gopactor) ShouldObserveTermination(param1 interface{}, params ...interf
 		}
 	}
 
-	return p.shouldObserveTermination(object, pid)
+	return catcher.FailureMessage(p.Expect(object).ToObserveTermination(pid).Verify())
+}
+
+// ShouldStopActor is an assertion method. Its rules are:
+// - The actor should send a Stop system message to another actor.
+// - If no PID is given, any actor will suffice.
+// - Outbound system interception should be enabled.
+func (p *Gopactor) ShouldStopActor(param1 interface{}, params ...interface{}) string {
+	return p.shouldSendSysMsg(param1, params, (*Expectation).ToStopActor)
+}
+
+// ShouldWatch is an assertion method. Its rules are:
+// - The actor should start watching another actor.
+// - If no PID is given, any actor will suffice.
+// - Outbound system interception should be enabled.
+func (p *Gopactor) ShouldWatch(param1 interface{}, params ...interface{}) string {
+	return p.shouldSendSysMsg(param1, params, (*Expectation).ToWatch)
+}
+
+// ShouldUnwatch is an assertion method. Its rules are:
+// - The actor should stop watching another actor.
+// - If no PID is given, any actor will suffice.
+// - Outbound system interception should be enabled.
+func (p *Gopactor) ShouldUnwatch(param1 interface{}, params ...interface{}) string {
+	return p.shouldSendSysMsg(param1, params, (*Expectation).ToUnwatch)
+}
+
+// ShouldPoison is an assertion method. Its rules are:
+// - The actor should send a PoisonPill to another actor.
+// - If no PID is given, any actor will suffice.
+// - Outbound system interception should be enabled.
+func (p *Gopactor) ShouldPoison(param1 interface{}, params ...interface{}) string {
+	return p.shouldSendSysMsg(param1, params, (*Expectation).ToPoison)
+}
+
+func (p *Gopactor) shouldSendSysMsg(param1 interface{}, params []interface{}, expect func(*Expectation, *actor.PID) *Expectation) string {
+	sender, ok := param1.(*actor.PID)
+	if !ok {
+		return "Sender is not an actor PID"
+	}
+
+	var target *actor.PID
+	if len(params) == 1 {
+		target, ok = params[0].(*actor.PID)
+		if !ok {
+			return "Parameter should be an actor PID"
+		}
+	} else if len(params) > 1 {
+		return "Only one parameter with a target PID is allowed"
+	}
+
+	return catcher.FailureMessage(expect(p.Expect(sender), target).Verify())
 }
 
 // ShouldSend is an assertion method. Its rules are:
@@ -156,7 +415,7 @@ func (p *Gopactor) ShouldSend(param1 interface{}, params ...interface{}) string
 
 	expectedMsg := params[0]
 
-	return p.shouldSend(sender, nil, expectedMsg)
+	return catcher.FailureMessage(p.Expect(sender).ToSend(expectedMsg).Verify())
 }
 
 // ShouldSendTo is an assertion method. Its rules are:
@@ -179,7 +438,58 @@ func (p *Gopactor) ShouldSendTo(param1 interface{}, params ...interface{}) strin
 
 	expectedMsg := params[1]
 
-	return p.shouldSend(sender, receiver, expectedMsg)
+	return catcher.FailureMessage(p.Expect(sender).ToSend(expectedMsg).To(receiver).Verify())
+}
+
+// ShouldEventuallySend is an assertion method. Its rules are:
+// - The sender should send a given message before the timeout expires.
+// - Other messages sent in the meantime are discarded.
+// - Optional parameters: a custom timeout (time.Duration) and KeepSkipped
+//   to keep other messages for the following assertions.
+func (p *Gopactor) ShouldEventuallySend(param1 interface{}, params ...interface{}) string {
+	sender, ok := param1.(*actor.PID)
+	if !ok {
+		return "Sender is not an actor PID"
+	}
+
+	if len(params) < 1 {
+		return "One parameter with a message is required to assert sending"
+	}
+
+	e := p.Expect(sender).ToEventuallySend(params[0])
+	return verifyWithModifiers(e, params[1:])
+}
+
+// ShouldSendAll is an assertion method. Its rules are:
+// - The sender should send all given messages in any order.
+// - Other messages are allowed.
+func (p *Gopactor) ShouldSendAll(param1 interface{}, params ...interface{}) string {
+	sender, ok := param1.(*actor.PID)
+	if !ok {
+		return "Sender is not an actor PID"
+	}
+
+	if len(params) == 0 {
+		return "At least one expected message is required"
+	}
+
+	return catcher.FailureMessage(p.Expect(sender).ToSendAll(params...).Verify())
+}
+
+// ShouldSendInAnyOrder is an assertion method. Its rules are:
+// - The sender should send exactly the given messages in any order.
+// - Other messages are not allowed.
+func (p *Gopactor) ShouldSendInAnyOrder(param1 interface{}, params ...interface{}) string {
+	sender, ok := param1.(*actor.PID)
+	if !ok {
+		return "Sender is not an actor PID"
+	}
+
+	if len(params) == 0 {
+		return "At least one expected message is required"
+	}
+
+	return catcher.FailureMessage(p.Expect(sender).ToSendInAnyOrder(params...).Verify())
 }
 
 // ShouldSendSomething is an assertion method. Its rules are:
@@ -191,7 +501,7 @@ func (p *Gopactor) ShouldSendSomething(param1 interface{}, _ ...interface{}) str
 		return "Sender is not an actor PID"
 	}
 
-	return p.shouldSend(sender, nil, nil)
+	return catcher.FailureMessage(p.Expect(sender).ToSend(nil).Verify())
 }
 
 // ShouldSendN is an assertion method. Its rules are:
@@ -213,8 +523,8 @@ func (p *Gopactor) ShouldSendN(param1 interface{}, params ...interface{}) string
 	}
 
 	for i := 0; i < expectedMessages; i++ {
-		res := p.shouldSend(sender, nil, nil)
-		if res != "" {
+		err := p.Expect(sender).ToSend(nil).Verify()
+		if err != nil {
 			return fmt.Sprintf("Expected %d messages to be sent, but got %d", expectedMessages, i)
 		}
 	}
@@ -230,7 +540,141 @@ func (p *Gopactor) ShouldNotSendOrReceive(param1 interface{}, _ ...interface{})
 		return "Object is not an actor PID"
 	}
 
-	return p.shouldNotSendOrReceive(object)
+	return catcher.FailureMessage(p.Expect(object).NotToSendOrReceive().Verify())
+}
+
+// ShouldNotReceive is an assertion method. Its rules are:
+// - The receiver should not receive a given message within the timeout.
+// - If no message is given, the receiver should not receive anything at all.
+// - Other messages are allowed.
+func (p *Gopactor) ShouldNotReceive(param1 interface{}, params ...interface{}) string {
+	receiver, ok := param1.(*actor.PID)
+	if !ok {
+		return "Receiver is not an actor PID"
+	}
+
+	if len(params) > 1 {
+		return "At most one parameter with a message is allowed"
+	}
+
+	var forbiddenMsg interface{}
+	if len(params) == 1 {
+		forbiddenMsg = params[0]
+	}
+
+	return catcher.FailureMessage(p.Expect(receiver).NotToReceive(forbiddenMsg).Verify())
+}
+
+// ShouldNotReceiveFrom is an assertion method. Its rules are:
+// - The receiver should not receive a given message from a given sender within the timeout.
+// - If no message is given, the receiver should not receive anything from the sender.
+// - Other messages are allowed.
+func (p *Gopactor) ShouldNotReceiveFrom(param1 interface{}, params ...interface{}) string {
+	receiver, ok := param1.(*actor.PID)
+	if !ok {
+		return "Receiver is not an actor PID"
+	}
+
+	if len(params) < 1 || len(params) > 2 {
+		return "A sender and an optional message are required"
+	}
+
+	sender, ok := params[0].(*actor.PID)
+	if !ok {
+		return "Sender should be an actor PID"
+	}
+
+	var forbiddenMsg interface{}
+	if len(params) == 2 {
+		forbiddenMsg = params[1]
+	}
+
+	return catcher.FailureMessage(p.Expect(receiver).NotToReceive(forbiddenMsg).From(sender).Verify())
+}
+
+// ShouldNotSend is an assertion method. Its rules are:
+// - The sender should not send a given message within the timeout.
+// - If no message is given, the sender should not send anything at all.
+// - Other messages are allowed.
+func (p *Gopactor) ShouldNotSend(param1 interface{}, params ...interface{}) string {
+	sender, ok := param1.(*actor.PID)
+	if !ok {
+		return "Sender is not an actor PID"
+	}
+
+	if len(params) > 1 {
+		return "At most one parameter with a message is allowed"
+	}
+
+	var forbiddenMsg interface{}
+	if len(params) == 1 {
+		forbiddenMsg = params[0]
+	}
+
+	return catcher.FailureMessage(p.Expect(sender).NotToSend(forbiddenMsg).Verify())
+}
+
+// ShouldNotSendTo is an assertion method. Its rules are:
+// - The sender should not send a given message to a given receiver within the timeout.
+// - If no message is given, the sender should not send anything to the receiver.
+// - Other messages are allowed.
+func (p *Gopactor) ShouldNotSendTo(param1 interface{}, params ...interface{}) string {
+	sender, ok := param1.(*actor.PID)
+	if !ok {
+		return "Sender is not an actor PID"
+	}
+
+	if len(params) < 1 || len(params) > 2 {
+		return "A receiver and an optional message are required"
+	}
+
+	receiver, ok := params[0].(*actor.PID)
+	if !ok {
+		return "Receiver should be an actor PID"
+	}
+
+	var forbiddenMsg interface{}
+	if len(params) == 2 {
+		forbiddenMsg = params[1]
+	}
+
+	return catcher.FailureMessage(p.Expect(sender).NotToSend(forbiddenMsg).To(receiver).Verify())
+}
+
+// ShouldNotSpawn is an assertion method. Its rules are:
+// - The actor should not spawn a child within the timeout.
+// - If a substring is given, only children with PIDs containing it are forbidden.
+func (p *Gopactor) ShouldNotSpawn(param1 interface{}, params ...interface{}) string {
+	object, ok := param1.(*actor.PID)
+	if !ok {
+		return "Object is not an actor PID"
+	}
+
+	if len(params) > 1 {
+		return "At most one parameter with a substring is allowed"
+	}
+
+	var match string
+	if len(params) == 1 {
+		var ok bool
+		match, ok = params[0].(string)
+		if !ok {
+			return "Parameter should be a string"
+		}
+	}
+
+	return catcher.FailureMessage(p.Expect(object).NotToSpawn(match).Verify())
+}
+
+// ShouldNotStop is an assertion method. Its rules are:
+// - The actor should not receive a system message that indicates the actor has been stopped.
+func (p *Gopactor) ShouldNotStop(param1 interface{}, _ ...interface{}) string {
+	pid, ok := param1.(*actor.PID)
+	if !ok {
+		return "Object is not an actor PID"
+	}
+
+	return catcher.FailureMessage(p.Expect(pid).NotToStop().Verify())
 }
 
 // ShouldSpawn is an assertion method. Its rules are:
@@ -242,6 +686,10 @@ func (p *Gopactor) ShouldSpawn(param1 interface{}, params ...interface{}) string
 		return "Object is not an actor PID"
 	}
 
+	if len(params) > 1 {
+		return "At most one parameter with a substring is allowed"
+	}
+
 	var match string
 	if len(params) == 1 {
 		var ok bool
@@ -251,5 +699,82 @@ func (p *Gopactor) ShouldSpawn(param1 interface{}, params ...interface{}) string
 		}
 	}
 
-	return p.shouldSpawn(object, match)
+	return catcher.FailureMessage(p.Expect(object).ToSpawn(match).Verify())
+}
+
+// ShouldResumeChild is an assertion method. Its rules are:
+// - The supervisor strategy of the actor should resume a failed child.
+// - If a child PID is given, the decision should be about this child.
+func (p *Gopactor) ShouldResumeChild(param1 interface{}, params ...interface{}) string {
+	return p.shouldDecideWithParams(param1, params, actor.ResumeDirective)
+}
+
+// ShouldRestartChild is an assertion method. Its rules are:
+// - The supervisor strategy of the actor should restart a failed child.
+// - If a child PID is given, the decision should be about this child.
+func (p *Gopactor) ShouldRestartChild(param1 interface{}, params ...interface{}) string {
+	return p.shouldDecideWithParams(param1, params, actor.RestartDirective)
+}
+
+// ShouldStopChild is an assertion method. Its rules are:
+// - The supervisor strategy of the actor should stop a failed child.
+// - If a child PID is given, the decision should be about this child.
+func (p *Gopactor) ShouldStopChild(param1 interface{}, params ...interface{}) string {
+	return p.shouldDecideWithParams(param1, params, actor.StopDirective)
+}
+
+// ShouldEscalate is an assertion method. Its rules are:
+// - The supervisor strategy of the actor should escalate a failure of a child.
+// - If a child PID is given, the failure should be of this child.
+func (p *Gopactor) ShouldEscalate(param1 interface{}, params ...interface{}) string {
+	return p.shouldDecideWithParams(param1, params, actor.EscalateDirective)
+}
+
+func (p *Gopactor) shouldDecideWithParams(param1 interface{}, params []interface{}, directive actor.Directive) string {
+	parent, ok := param1.(*actor.PID)
+	if !ok {
+		return "Object is not an actor PID"
+	}
+
+	if len(params) > 1 {
+		return "At most one parameter with an actor PID is allowed"
+	}
+
+	var child *actor.PID
+	if len(params) == 1 {
+		var ok bool
+		child, ok = params[0].(*actor.PID)
+		if !ok {
+			return "Parameter should be an actor PID"
+		}
+	}
+
+	return catcher.FailureMessage(p.Expect(parent).ToDecide(child, directive).Verify())
+}
+
+// Modifier is an optional parameter which changes the behavior of an assertion.
+type Modifier int
+
+const (
+	// KeepSkipped tells an eventual assertion to keep the skipped messages
+	// for the following assertions instead of discarding them.
+	KeepSkipped Modifier = iota + 1
+)
+
+func verifyWithModifiers(e *Expectation, params []interface{}) string {
+	var timeout time.Duration
+	for _, param := range params {
+		switch param := param.(type) {
+		case time.Duration:
+			timeout = param
+		case Modifier:
+			if param == KeepSkipped {
+				e.KeepingOthers()
+			}
+		default:
+			return fmt.Sprintf("Unknown parameter: %#v", param)
+		}
+	}
+
+	return catcher.FailureMessage(e.Within(timeout))
 }
@@ -0,0 +1,82 @@
+package gopactor
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/catcher"
+)
+
+// CaptureReceived waits for the next message received by the actor
+// and stores it into the target, which must be a pointer to a variable
+// of the message type. The envelope is returned along with the sender
+// and the target, even if the message is of another type.
+//   var resp *Response
+//   envelope, err := p.CaptureReceived(worker, &resp)
+func (p *Gopactor) CaptureReceived(pid *actor.PID, target interface{}) (*catcher.Envelope, error) {
+	return p.capture(pid, catcher.KindUserInbound, "Receiver", target)
+}
+
+// CaptureSent waits for the next message sent by the actor
+// and stores it into the target, just like CaptureReceived does.
+func (p *Gopactor) CaptureSent(pid *actor.PID, target interface{}) (*catcher.Envelope, error) {
+	return p.capture(pid, catcher.KindUserOutbound, "Sender", target)
+}
+
+func (p *Gopactor) capture(pid *actor.PID, kind catcher.Kind, what string, target interface{}) (*catcher.Envelope, error) {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return nil, &catcher.Failure{Reason: "Target should be a non-nil pointer"}
+	}
+
+	if pid == nil {
+		return nil, &catcher.Failure{Reason: what + " is not an actor PID"}
+	}
+
+	c := p.getCatcherByPID(pid)
+	if c == nil {
+		return nil, &catcher.Failure{Reason: what + " is not registered in Gopactor"}
+	}
+
+	envelope, err := c.Capture(kind, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	elem := value.Elem()
+	if envelope.Message == nil {
+		if !canBeNil(elem.Kind()) {
+			return envelope, typeMismatch(elem.Type(), envelope)
+		}
+
+		elem.Set(reflect.Zero(elem.Type()))
+		return envelope, nil
+	}
+
+	msg := reflect.ValueOf(envelope.Message)
+	if !msg.Type().AssignableTo(elem.Type()) {
+		return envelope, typeMismatch(elem.Type(), envelope)
+	}
+
+	elem.Set(msg)
+	return envelope, nil
+}
+
+func canBeNil(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		return true
+	}
+
+	return false
+}
+
+func typeMismatch(expected reflect.Type, envelope *catcher.Envelope) *catcher.Failure {
+	return &catcher.Failure{
+		Reason:   "Message type does not match",
+		Expected: expected.String(),
+		Actual:   fmt.Sprintf("%T", envelope.Message),
+		Envelope: envelope,
+	}
+}
@@ -0,0 +1,35 @@
+//go:build go1.18
+// +build go1.18
+
+package gopactor
+
+import (
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/catcher"
+)
+
+// ReceiveAs waits for the next message received by the actor and returns it
+// typed, along with the envelope. The envelope is returned even if the message
+// is of another type:
+//   resp, envelope, err := ReceiveAs[*Response](p, worker)
+func ReceiveAs[T any](p *Gopactor, pid *actor.PID) (T, catcher.Envelope, error) {
+	var msg T
+	envelope, err := p.CaptureReceived(pid, &msg)
+	return msg, envelopeValue(envelope), err
+}
+
+// SentAs waits for the next message sent by the actor and returns it
+// typed, along with the envelope, just like ReceiveAs does.
+func SentAs[T any](p *Gopactor, pid *actor.PID) (T, catcher.Envelope, error) {
+	var msg T
+	envelope, err := p.CaptureSent(pid, &msg)
+	return msg, envelopeValue(envelope), err
+}
+
+func envelopeValue(envelope *catcher.Envelope) catcher.Envelope {
+	if envelope == nil {
+		return catcher.Envelope{}
+	}
+
+	return *envelope
+}
@@ -0,0 +1,331 @@
+package gopactor
+
+import (
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/catcher"
+	"github.com/meamidos/gopactor/matchers"
+)
+
+// Expectation is a typed assertion about an actor. It is built step by step
+// and checked by Within or Verify. A failed check returns a *catcher.Failure:
+//   err := p.Expect(worker).ToReceive("ping").From(requestor).Within(time.Second)
+//   err := p.Expect(worker).ToSend("pong").To(requestor).Verify()
+//   err := p.Expect(worker).NotToSpawn("child").Verify()
+type Expectation struct {
+	p   *Gopactor
+	pid *actor.PID
+
+	// What is expected. Set by one of the To* methods.
+	verify func(c *catcher.Catcher, timeout time.Duration) error
+	what   string
+
+	msg       interface{}
+	peer      *actor.PID // The sender or the receiver
+	match     string
+	directive actor.Directive
+	keep      bool
+}
+
+// Expect starts an expectation about a given actor.
+func (p *Gopactor) Expect(pid *actor.PID) *Expectation {
+	return &Expectation{p: p, pid: pid}
+}
+
+// ToReceive expects the actor to receive a message.
+// A nil message means any message. The message can be a matcher.
+func (e *Expectation) ToReceive(msg interface{}) *Expectation {
+	e.msg = msg
+	return e.expect("Receiver", func(c *catcher.Catcher, timeout time.Duration) error {
+		return c.AssertReceive(e.peer, e.msg, timeout)
+	})
+}
+
+// NotToReceive expects the actor not to receive a message.
+// A nil message means any message.
+func (e *Expectation) NotToReceive(msg interface{}) *Expectation {
+	e.msg = msg
+	return e.expect("Receiver", func(c *catcher.Catcher, timeout time.Duration) error {
+		return c.AssertNotReceive(e.peer, e.msg, timeout)
+	})
+}
+
+// ToSend expects the actor to send a message.
+// A nil message means any message. The message can be a matcher.
+func (e *Expectation) ToSend(msg interface{}) *Expectation {
+	e.msg = msg
+	return e.expect("Sender", func(c *catcher.Catcher, timeout time.Duration) error {
+		return c.AssertSend(e.peer, e.msg, timeout)
+	})
+}
+
+// NotToSend expects the actor not to send a message.
+// A nil message means any message.
+func (e *Expectation) NotToSend(msg interface{}) *Expectation {
+	e.msg = msg
+	return e.expect("Sender", func(c *catcher.Catcher, timeout time.Duration) error {
+		return c.AssertNotSend(e.peer, e.msg, timeout)
+	})
+}
+
+// NotToSendOrReceive expects the actor neither to send nor to receive anything.
+func (e *Expectation) NotToSendOrReceive() *Expectation {
+	return e.expect("Object", func(c *catcher.Catcher, timeout time.Duration) error {
+		return c.AssertNotSendOrReceive(timeout)
+	})
+}
+
+// ToEventuallyReceive expects the actor to receive a message sooner or later.
+// Messages which do not match are skipped.
+func (e *Expectation) ToEventuallyReceive(msg interface{}) *Expectation {
+	e.msg = msg
+	return e.expect("Receiver", func(c *catcher.Catcher, timeout time.Duration) error {
+		return c.AssertEventually(catcher.KindUserInbound, e.peer, e.msg, e.keep, timeout)
+	})
+}
+
+// ToEventuallySend expects the actor to send a message sooner or later.
+// Messages which do not match are skipped.
+func (e *Expectation) ToEventuallySend(msg interface{}) *Expectation {
+	e.msg = msg
+	return e.expect("Sender", func(c *catcher.Catcher, timeout time.Duration) error {
+		return c.AssertEventually(catcher.KindUserOutbound, e.peer, e.msg, e.keep, timeout)
+	})
+}
+
+// KeepingOthers makes an eventual expectation keep the skipped messages
+// instead of discarding them, so that they can be asserted afterwards.
+func (e *Expectation) KeepingOthers() *Expectation {
+	e.keep = true
+	return e
+}
+
+// ToReceiveAll expects the actor to receive all given messages in any order.
+// Other messages received in the meantime are ignored.
+// An expected message can be a matcher, or a *catcher.Envelope
+// to check the sender as well.
+func (e *Expectation) ToReceiveAll(expected ...interface{}) *Expectation {
+	return e.expect("Receiver", func(c *catcher.Catcher, timeout time.Duration) error {
+		return c.AssertAll(catcher.KindUserInbound, expected, false, timeout)
+	})
+}
+
+// ToSendAll expects the actor to send all given messages in any order.
+// Other messages sent in the meantime are ignored.
+// An expected message can be a matcher, or a *catcher.Envelope
+// to check the receiver as well.
+func (e *Expectation) ToSendAll(expected ...interface{}) *Expectation {
+	return e.expect("Sender", func(c *catcher.Catcher, timeout time.Duration) error {
+		return c.AssertAll(catcher.KindUserOutbound, expected, false, timeout)
+	})
+}
+
+// ToSendInAnyOrder expects the actor to send exactly the given messages in any order.
+// Any other message sent in the meantime fails the expectation.
+func (e *Expectation) ToSendInAnyOrder(expected ...interface{}) *Expectation {
+	return e.expect("Sender", func(c *catcher.Catcher, timeout time.Duration) error {
+		return c.AssertAll(catcher.KindUserOutbound, expected, true, timeout)
+	})
+}
+
+// ToSpawn expects the actor to spawn a child whose PID contains a given substring.
+// An empty string means any child.
+func (e *Expectation) ToSpawn(match string) *Expectation {
+	e.match = match
+	return e.expect("Object", func(c *catcher.Catcher, timeout time.Duration) error {
+		return c.AssertSpawn(e.match, timeout)
+	})
+}
+
+// NotToSpawn expects the actor not to spawn a child whose PID contains a given substring.
+// An empty string means any child.
+func (e *Expectation) NotToSpawn(match string) *Expectation {
+	e.match = match
+	return e.expect("Object", func(c *catcher.Catcher, timeout time.Duration) error {
+		return c.AssertNotSpawn(e.match, timeout)
+	})
+}
+
+// ToReceiveSysMsg expects the actor to receive a system message.
+// Other system messages received in the meantime are skipped.
+func (e *Expectation) ToReceiveSysMsg(msg interface{}) *Expectation {
+	e.msg = msg
+	return e.expect("Receiver", func(c *catcher.Catcher, timeout time.Duration) error {
+		return c.AssertReceiveSysMsg(e.msg, timeout)
+	})
+}
+
+// NotToReceiveSysMsg expects the actor not to receive a system message.
+func (e *Expectation) NotToReceiveSysMsg(msg interface{}) *Expectation {
+	e.msg = msg
+	return e.expect("Receiver", func(c *catcher.Catcher, timeout time.Duration) error {
+		return c.AssertNotReceiveSysMsg(e.msg, timeout)
+	})
+}
+
+// ToStart expects the actor to be started.
+func (e *Expectation) ToStart() *Expectation {
+	return e.ToReceiveSysMsg(&actor.Started{})
+}
+
+// ToStop expects the actor to be stopped.
+func (e *Expectation) ToStop() *Expectation {
+	return e.ToReceiveSysMsg(&actor.Stopped{})
+}
+
+// NotToStop expects the actor not to be stopped.
+func (e *Expectation) NotToStop() *Expectation {
+	return e.NotToReceiveSysMsg(&actor.Stopped{})
+}
+
+// ToBeStopping expects the actor to be about to stop.
+func (e *Expectation) ToBeStopping() *Expectation {
+	return e.ToReceiveSysMsg(&actor.Stopping{})
+}
+
+// ToReceiveRestart expects the actor to be told to restart by its supervisor.
+func (e *Expectation) ToReceiveRestart() *Expectation {
+	return e.ToReceiveSysMsg(&actor.Restart{})
+}
+
+// ToReceiveTimeout expects the receive timeout of the actor to expire.
+func (e *Expectation) ToReceiveTimeout() *Expectation {
+	return e.ToReceiveSysMsg(&actor.ReceiveTimeout{})
+}
+
+// ToBePoisoned expects the actor to receive a PoisonPill.
+func (e *Expectation) ToBePoisoned() *Expectation {
+	return e.ToReceiveSysMsg(&actor.PoisonPill{})
+}
+
+// ToBeWatched expects another actor to start watching the actor.
+// A nil PID means any watcher. The watcher should be spawned by Gopactor.
+func (e *Expectation) ToBeWatched(watcher *actor.PID) *Expectation {
+	return e.ToReceiveSysMsg(&actor.Watch{Watcher: watcher})
+}
+
+// ToBeUnwatched expects another actor to stop watching the actor.
+// A nil PID means any watcher. The watcher should be spawned by Gopactor.
+func (e *Expectation) ToBeUnwatched(watcher *actor.PID) *Expectation {
+	return e.ToReceiveSysMsg(&actor.Unwatch{Watcher: watcher})
+}
+
+// ToObserveFailure expects a child of the actor to fail.
+// A nil PID means any child.
+func (e *Expectation) ToObserveFailure(child *actor.PID) *Expectation {
+	if child != nil {
+		return e.ToReceiveSysMsg(&actor.Failure{Who: child})
+	}
+
+	// Failures of the actor itself come through the same way
+	return e.ToReceiveSysMsg(matchers.Func(func(msg interface{}) bool {
+		failure, ok := msg.(*actor.Failure)
+		return ok && !failure.Who.Equal(e.pid)
+	}))
+}
+
+// ToBeRestarting expects the actor to be restarted.
+func (e *Expectation) ToBeRestarting() *Expectation {
+	return e.ToReceiveSysMsg(&actor.Restarting{})
+}
+
+// ToObserveTermination expects the actor to be notified that another actor
+// has been terminated. A nil PID means any actor.
+func (e *Expectation) ToObserveTermination(pid *actor.PID) *Expectation {
+	return e.ToReceiveSysMsg(&actor.Terminated{Who: pid})
+}
+
+// ToFail expects the actor to panic. A nil reason means any reason.
+// The reason can be a matcher.
+func (e *Expectation) ToFail(reason interface{}) *Expectation {
+	return e.ToReceiveSysMsg(&actor.Failure{Who: e.pid, Reason: reason})
+}
+
+// NotToFail expects the actor not to panic.
+func (e *Expectation) NotToFail() *Expectation {
+	return e.NotToReceiveSysMsg(&actor.Failure{Who: e.pid})
+}
+
+// ToSendSysMsg expects the actor to send a system message, e.g. Stop,
+// Watch, Unwatch or PoisonPill. Other system messages sent in the meantime
+// are skipped. It requires outbound system interception.
+func (e *Expectation) ToSendSysMsg(msg interface{}) *Expectation {
+	e.msg = msg
+	return e.expect("Sender", func(c *catcher.Catcher, timeout time.Duration) error {
+		return c.AssertSendSysMsg(e.peer, e.msg, timeout)
+	})
+}
+
+// ToStopActor expects the actor to stop another actor. A nil PID means any actor.
+func (e *Expectation) ToStopActor(pid *actor.PID) *Expectation {
+	return e.To(pid).ToSendSysMsg(&actor.Stop{})
+}
+
+// ToWatch expects the actor to start watching another actor. A nil PID means any actor.
+func (e *Expectation) ToWatch(pid *actor.PID) *Expectation {
+	return e.To(pid).ToSendSysMsg(matchers.OfType(&actor.Watch{}))
+}
+
+// ToUnwatch expects the actor to stop watching another actor. A nil PID means any actor.
+func (e *Expectation) ToUnwatch(pid *actor.PID) *Expectation {
+	return e.To(pid).ToSendSysMsg(matchers.OfType(&actor.Unwatch{}))
+}
+
+// ToPoison expects the actor to send a PoisonPill to another actor. A nil PID means any actor.
+func (e *Expectation) ToPoison(pid *actor.PID) *Expectation {
+	return e.To(pid).ToSendSysMsg(&actor.PoisonPill{})
+}
+
+// ToDecide expects the supervisor strategy of the actor to make
+// a given decision about a failed child. A nil child means any child.
+func (e *Expectation) ToDecide(child *actor.PID, directive actor.Directive) *Expectation {
+	e.peer = child
+	e.directive = directive
+	return e.expect("Supervisor", func(c *catcher.Catcher, timeout time.Duration) error {
+		return c.AssertDecide(e.peer, e.directive, timeout)
+	})
+}
+
+// From narrows an expectation about receiving down to a given sender.
+func (e *Expectation) From(sender *actor.PID) *Expectation {
+	e.peer = sender
+	return e
+}
+
+// To narrows an expectation about sending down to a given receiver.
+func (e *Expectation) To(receiver *actor.PID) *Expectation {
+	e.peer = receiver
+	return e
+}
+
+// Within checks the expectation waiting no longer than a given time.
+// For negative expectations, it is the time during which nothing forbidden
+// should happen. A zero duration means the timeout from the actor's options.
+func (e *Expectation) Within(timeout time.Duration) error {
+	if e.verify == nil {
+		return &catcher.Failure{Reason: "Nothing is expected"}
+	}
+
+	if e.pid == nil {
+		return &catcher.Failure{Reason: e.what + " is not an actor PID"}
+	}
+
+	c := e.p.getCatcherByPID(e.pid)
+	if c == nil {
+		return &catcher.Failure{Reason: e.what + " is not registered in Gopactor"}
+	}
+
+	return e.verify(c, timeout)
+}
+
+// Verify checks the expectation using the timeout from the actor's options.
+func (e *Expectation) Verify() error {
+	return e.Within(0)
+}
+
+func (e *Expectation) expect(what string, verify func(c *catcher.Catcher, timeout time.Duration) error) *Expectation {
+	e.what = what
+	e.verify = verify
+	return e
+}
@@ -0,0 +1,424 @@
+package gopactor
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/meamidos/gopactor/catcher"
+	"github.com/meamidos/gopactor/trace"
+)
+
+// EXPLORE_MAX_RUNS is the default limit of runs of an exploration.
+const EXPLORE_MAX_RUNS = 100
+
+// EXPLORE_MAX_STEPS is the default limit of delivered messages in a single run.
+const EXPLORE_MAX_STEPS = 1000
+
+// EXPLORE_MAX_ESTIMATE caps the estimated number of schedules.
+const EXPLORE_MAX_ESTIMATE = 1 << 30
+
+// EXPLORE_SETTLE is the default time the actors should stay idle
+// before the pending messages are considered complete.
+// Messages among the actors are awaited through their mailboxes anyway,
+// so the time only matters for messages coming from elsewhere.
+const EXPLORE_SETTLE = 5 * time.Millisecond
+
+// Scenario spawns the actors of a single run with a fresh Gopactor instance
+// and sends the first messages. Only actors spawned with gating are explored,
+// and they should not be blocked by lock-step interception, e.g.:
+//   p.SpawnFromInstance(&Account{}, options.OptNoInterception.WithGating())
+type Scenario func(p *Gopactor)
+
+// Invariant checks the outcome of a run, once no messages are pending anymore.
+type Invariant func(p *Gopactor) error
+
+// ExploreOptions tune an exploration. Zero values mean defaults.
+type ExploreOptions struct {
+	MaxRuns  int
+	MaxSteps int
+
+	// The time the actors should stay idle before the pending messages
+	// are considered complete. Messages sent by the actors spawned
+	// in the scenario are awaited exactly, but messages from timers,
+	// other goroutines or actors not spawned by Gopactor are only caught
+	// if they arrive in time. Such scenarios depend on timing, and a longer
+	// settle time makes them more stable under load.
+	Settle time.Duration
+
+	// Random schedules are seeded with Seed, Seed+1 and so on, one per run.
+	// A zero seed is taken from the clock.
+	Seed int64
+
+	// Try random schedules only, even if the state space is small
+	Random bool
+
+	// Choices of a counterexample. The exploration runs this schedule only,
+	// and fails if it can not be followed.
+	Replay []Step
+}
+
+// Step is a choice of the message delivered next: the actor, by the order
+// in which the actors have been spawned, and the position of the message
+// among the ones parked by the actor, oldest first.
+type Step struct {
+	Actor   int
+	Message int
+}
+
+// Coverage tells how much of the state space an exploration has tried.
+// Only a complete exploration proves that the invariant holds in every order.
+type Coverage struct {
+	Runs int
+
+	// The number of schedules if every step of every run had as many
+	// pending messages as in the first run
+	Estimated int
+
+	// All schedules have been tried
+	Complete bool
+
+	// Random schedules have been tried, since there are too many
+	Random bool
+}
+
+func (c Coverage) String() string {
+	switch {
+	case c.Complete:
+		return fmt.Sprintf("All %d schedules tried", c.Runs)
+	case c.Random:
+		return fmt.Sprintf("%d random schedules tried out of about %d", c.Runs, c.Estimated)
+	}
+
+	return fmt.Sprintf("%d of about %d schedules tried", c.Runs, c.Estimated)
+}
+
+// Counterexample is a run which breaks the invariant
+type Counterexample struct {
+	Run int
+
+	// The seed of a random run, zero otherwise
+	Seed   int64
+	Random bool
+
+	// Delivered messages in order
+	Schedule []string
+
+	// The message delivered at every step
+	Choices []Step
+
+	Err error
+}
+
+func (c *Counterexample) Error() string {
+	var b bytes.Buffer
+
+	mode := "exhaustive"
+	if c.Random {
+		mode = fmt.Sprintf("random, seed %d", c.Seed)
+	}
+
+	fmt.Fprintf(&b, "Invariant failed in run %d (%s): %s\nSchedule:\n", c.Run, mode, c.Err)
+	for i, step := range c.Schedule {
+		fmt.Fprintf(&b, "  %d. %s\n", i+1, step)
+	}
+	fmt.Fprintf(&b, "Replay: ExploreOptions{Replay: %#v}", c.Choices)
+
+	return b.String()
+}
+
+// Explore runs the scenario again and again, each time delivering the messages
+// pending at gated actors in a different order, and checks the invariant after
+// every run. If the number of schedules estimated by the first run fits into
+// the limit of runs, all of them are tried. Otherwise, random schedules are tried.
+// The first run which breaks the invariant is returned as a *Counterexample.
+// The coverage tells whether all schedules have been tried, or only a sample.
+func Explore(scenario Scenario, invariant Invariant, opts ...ExploreOptions) (Coverage, error) {
+	var opt ExploreOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.MaxRuns <= 0 {
+		opt.MaxRuns = EXPLORE_MAX_RUNS
+	}
+	if opt.MaxSteps <= 0 {
+		opt.MaxSteps = EXPLORE_MAX_STEPS
+	}
+	if opt.Settle <= 0 {
+		opt.Settle = EXPLORE_SETTLE
+	}
+	if opt.Seed == 0 {
+		opt.Seed = time.Now().UnixNano()
+	}
+
+	replay := opt.Replay != nil
+	if replay {
+		opt.MaxRuns = 1
+	}
+
+	prefix := opt.Replay
+	coverage := Coverage{Random: opt.Random && !replay}
+
+	for run := 1; run <= opt.MaxRuns; run++ {
+		seed := opt.Seed + int64(run-1)
+
+		s := &schedule{prefix: prefix}
+		if coverage.Random {
+			s.rng = rand.New(rand.NewSource(seed))
+		}
+
+		coverage.Runs = run
+		if failure := s.run(scenario, invariant, opt); failure != nil {
+			return coverage, &catcher.Failure{Reason: fmt.Sprintf("Run %d: %s", run, failure.Reason)}
+		}
+
+		if run == 1 {
+			coverage.Estimated = s.estimate()
+		}
+
+		if s.violation != nil {
+			counterexample := &Counterexample{
+				Run:      run,
+				Random:   coverage.Random,
+				Schedule: s.steps,
+				Choices:  s.choices,
+				Err:      s.violation,
+			}
+			if coverage.Random {
+				counterexample.Seed = seed
+			}
+			return coverage, counterexample
+		}
+
+		if coverage.Random || replay {
+			continue
+		}
+
+		if run == 1 && coverage.Estimated > opt.MaxRuns {
+			coverage.Random = true
+			continue
+		}
+
+		if prefix = s.next(); prefix == nil {
+			coverage.Complete = true
+			return coverage, nil
+		}
+	}
+
+	return coverage, nil
+}
+
+// schedule chooses which pending message is delivered at every step of a run.
+// Actors are identified by the order of spawning, and messages by the order
+// of parking, so that a choice means the same in every run. Exhaustive schedules
+// follow the prefix and then choose the first message, random ones choose
+// with the generator.
+type schedule struct {
+	prefix []Step
+	rng    *rand.Rand
+
+	choices []Step
+	pending [][]Step
+	steps   []string
+
+	// The error of the invariant
+	violation error
+}
+
+// follows tells whether the message required by the prefix is pending
+func (s *schedule) follows(pending []Step) bool {
+	step := len(s.choices)
+	if s.rng != nil || step >= len(s.prefix) {
+		return true
+	}
+
+	return indexOf(pending, s.prefix[step]) >= 0
+}
+
+func (s *schedule) choose(pending []Step) Step {
+	step := len(s.choices)
+
+	choice := pending[0]
+	if s.rng != nil {
+		choice = pending[s.rng.Intn(len(pending))]
+	} else if step < len(s.prefix) {
+		choice = s.prefix[step]
+	}
+
+	s.choices = append(s.choices, choice)
+	s.pending = append(s.pending, pending)
+	return choice
+}
+
+// next returns the prefix of the next exhaustive schedule, or nil when all have been tried
+func (s *schedule) next() []Step {
+	for i := len(s.choices) - 1; i >= 0; i-- {
+		if j := indexOf(s.pending[i], s.choices[i]); j+1 < len(s.pending[i]) {
+			prefix := make([]Step, i+1)
+			copy(prefix, s.choices[:i])
+			prefix[i] = s.pending[i][j+1]
+			return prefix
+		}
+	}
+
+	return nil
+}
+
+func indexOf(steps []Step, step Step) int {
+	for i, other := range steps {
+		if other == step {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// estimate returns the number of schedules if every step of every run
+// had as many pending messages as in this run
+func (s *schedule) estimate() int {
+	total := 1
+	for _, pending := range s.pending {
+		total *= len(pending)
+		if total > EXPLORE_MAX_ESTIMATE {
+			return EXPLORE_MAX_ESTIMATE
+		}
+	}
+
+	return total
+}
+
+type pendingMessage struct {
+	step   Step
+	parked *catcher.ParkedMessage
+}
+
+// run returns a failure if the run could not be completed
+func (s *schedule) run(scenario Scenario, invariant Invariant, opt ExploreOptions) *catcher.Failure {
+	p := New()
+	defer p.Close(CLEANUP_TIMEOUT)
+
+	scenario(p)
+
+	for {
+		pending, failure := p.settle(opt.Settle)
+		if failure != nil {
+			return failure
+		}
+
+		// Make sure the run is over, or the required message is missing,
+		// rather than the actors being slow
+		if len(pending) == 0 || !s.follows(steps(pending)) {
+			if pending, failure = p.settle(10 * opt.Settle); failure != nil {
+				return failure
+			}
+		}
+
+		if len(pending) == 0 {
+			break
+		}
+
+		if !s.follows(steps(pending)) {
+			required := s.prefix[len(s.choices)]
+			return &catcher.Failure{Reason: fmt.Sprintf(
+				"The schedule can not be followed at step %d: actor %d has no message %d parked. Is the scenario deterministic?",
+				len(s.choices)+1, required.Actor, required.Message)}
+		}
+
+		if len(s.choices) >= opt.MaxSteps {
+			s.violation = fmt.Errorf("Run did not finish within %d steps", opt.MaxSteps)
+			return nil
+		}
+
+		next := pending[indexOf(steps(pending), s.choose(steps(pending)))]
+
+		envelope := next.parked.Envelope
+		s.steps = append(s.steps, fmt.Sprintf("%s -> %s: %s", sender(envelope), envelope.Target, trace.Label(envelope.Message)))
+		next.parked.Release()
+	}
+
+	s.violation = invariant(p)
+	return nil
+}
+
+func steps(pending []pendingMessage) []Step {
+	steps := make([]Step, len(pending))
+	for i, message := range pending {
+		steps[i] = message.step
+	}
+
+	return steps
+}
+
+// settle waits until all actors are either idle or have parked their messages.
+// Every round sends a marker through the mailboxes of the actors, so messages
+// among the actors followed by the instance are never missed. The actors should
+// also stay idle for the quiet time, which gives messages from elsewhere,
+// e.g. timers or actors not spawned by Gopactor, a chance to arrive.
+// The pending messages are returned in order of registration of the actors,
+// and then in order of parking, so that the choices of a schedule
+// mean the same in every run.
+func (p *Gopactor) settle(quiet time.Duration) ([]pendingMessage, *catcher.Failure) {
+	deadline := time.Now().Add(CLEANUP_TIMEOUT)
+	since := time.Now()
+
+	for {
+		if time.Now().After(deadline) {
+			return nil, &catcher.Failure{Reason: "Actors are still busy. Are they blocked by lock-step interception?"}
+		}
+
+		catchers := p.catchers()
+		before := activity(catchers)
+
+		for _, c := range catchers {
+			select {
+			case <-c.Sync():
+			case <-time.After(time.Until(deadline)):
+			}
+		}
+
+		active := false
+		for _, c := range catchers {
+			active = active || c.Busy() || c.InFlight()
+		}
+
+		if active || activity(catchers) != before {
+			since = time.Now()
+			continue
+		}
+
+		if time.Since(since) >= quiet {
+			break
+		}
+
+		time.Sleep(quiet / 10)
+	}
+
+	pending := []pendingMessage{}
+	for i, c := range p.catchers() {
+		for j, parked := range c.ParkedMessages() {
+			pending = append(pending, pendingMessage{Step{i, j}, parked})
+		}
+	}
+
+	return pending, nil
+}
+
+// activity sums up the messages handled and parked by the actors
+func activity(catchers []*catcher.Catcher) int {
+	total := 0
+	for _, c := range catchers {
+		total += int(c.Handled()) + len(c.ParkedMessages())
+	}
+
+	return total
+}
+
+func sender(envelope *catcher.Envelope) string {
+	if envelope.Sender == nil {
+		return trace.UNKNOWN_SENDER
+	}
+
+	return envelope.Sender.String()
+}
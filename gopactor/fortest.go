@@ -0,0 +1,168 @@
+package gopactor
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/catcher"
+)
+
+// CLEANUP_TIMEOUT is the time given to actors to stop
+// when a Gopactor instance created by ForTest is cleaned up.
+const CLEANUP_TIMEOUT = time.Second
+
+// TestingT is the part of *testing.T used by ForTest.
+// Cleanup is available in *testing.T since Go 1.14.
+type TestingT interface {
+	Cleanup(func())
+	Errorf(format string, args ...interface{})
+	Logf(format string, args ...interface{})
+	Failed() bool
+	Name() string
+}
+
+// ForTest creates an isolated Gopactor instance for a single test.
+// Actors spawned by it are not visible to other instances, so parallel
+// subtests do not interfere with each other. When the test finishes,
+// the instance is closed, and the test fails if some actors
+// can not be stopped in time or are stuck handling a message,
+// or if goroutines started by the actors are left behind.
+// Only goroutines traced back to the actors of the instance are checked,
+// so other tests running in parallel do not matter. Tracing relies on
+// the runtime telling the parent of a goroutine, which it does since Go 1.21.
+// If the test fails, the conversation among the actors is written
+// to sequence diagrams in TRACE_DIR, and their paths are logged.
+func ForTest(t TestingT) *Gopactor {
+	p := New()
+	t.Cleanup(func() {
+		conversation := p.Trace()
+		catchers := p.catchers()
+
+		if err := p.Close(CLEANUP_TIMEOUT); err != nil {
+			t.Errorf("Gopactor cleanup failed: %s", err)
+		} else if leaked := leakedGoroutines(actorGoroutines(catchers), CLEANUP_TIMEOUT); len(leaked) > 0 {
+			t.Errorf("Gopactor cleanup failed: %d goroutines are left behind:\n\n%s", len(leaked), strings.Join(leaked, "\n\n"))
+		}
+
+		if !t.Failed() || len(conversation.Events) == 0 {
+			return
+		}
+
+		paths, err := writeTrace(conversation, t.Name())
+		if err != nil {
+			t.Logf("Gopactor could not write the trace: %s", err)
+		}
+		for _, path := range paths {
+			t.Logf("Gopactor trace: %s", path)
+		}
+	})
+
+	return p
+}
+
+// Close unblocks all catchers, stops all actors spawned by the instance,
+// including intercepted children, and waits for them to stop.
+// An error is returned if some actors are still alive or still busy
+// handling a message when the timeout expires. Goroutines started by the actors
+// themselves are not stopped: ForTest checks that they are gone.
+// The instance is reset afterwards.
+func (p *Gopactor) Close(timeout time.Duration) error {
+	p.mu.Lock()
+	catchers := p.registered
+	p.CatchersByPID = make(map[string]*catcher.Catcher)
+	p.registered = nil
+	p.mu.Unlock()
+
+	pids := make([]*actor.PID, 0, len(catchers))
+	for _, c := range catchers {
+		c.Close()
+		if pid := c.AssignedPID(); pid != nil {
+			pids = append(pids, pid)
+		}
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	if alive := stopAndWait(pids, timeout); alive > 0 {
+		return fmt.Errorf("%d of %d actors did not stop within %s", alive, len(pids), timeout)
+	}
+
+	for {
+		busy := 0
+		for _, c := range catchers {
+			if c.Busy() {
+				busy++
+			}
+		}
+
+		if busy == 0 {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%d actors are still busy handling a message after %s", busy, timeout)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// actorGoroutines returns the IDs of the goroutines which have run the actors
+func actorGoroutines(catchers []*catcher.Catcher) []uint64 {
+	ids := []uint64{}
+	for _, c := range catchers {
+		ids = append(ids, c.Goroutines()...)
+	}
+	return ids
+}
+
+// stopAndWait stops the actors and waits for their termination.
+// It returns the number of actors which are still alive.
+func stopAndWait(pids []*actor.PID, timeout time.Duration) int {
+	if len(pids) == 0 {
+		return 0
+	}
+
+	var mu sync.Mutex
+	alive := make(map[string]bool, len(pids))
+	for _, pid := range pids {
+		alive[pid.String()] = true
+	}
+
+	stopped := make(chan struct{})
+	watcher := actor.Spawn(actor.FromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case *actor.Started:
+			for _, pid := range pids {
+				ctx.Watch(pid)
+			}
+		case *actor.Terminated:
+			mu.Lock()
+			defer mu.Unlock()
+
+			if alive[msg.Who.String()] {
+				delete(alive, msg.Who.String())
+				if len(alive) == 0 {
+					close(stopped)
+				}
+			}
+		}
+	}))
+	defer watcher.Stop()
+
+	for _, pid := range pids {
+		pid.Stop()
+	}
+
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	return len(alive)
+}
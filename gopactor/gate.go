@@ -0,0 +1,70 @@
+package gopactor
+
+import (
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/catcher"
+)
+
+// Release lets through the oldest message parked by a gated actor.
+// It waits for the actor to park a message up to the timeout from the options.
+func (p *Gopactor) Release(pid *actor.PID) error {
+	return p.gate(pid, func(c *catcher.Catcher) error {
+		parked, err := c.Park(0)
+		if err == nil {
+			parked.Release()
+		}
+		return err
+	})
+}
+
+// Drop discards the oldest message parked by a gated actor.
+// The message is never delivered to the actor, nor intercepted.
+func (p *Gopactor) Drop(pid *actor.PID) error {
+	return p.gate(pid, func(c *catcher.Catcher) error {
+		parked, err := c.Park(0)
+		if err == nil {
+			parked.Drop()
+		}
+		return err
+	})
+}
+
+// Replace delivers another message to a gated actor
+// instead of the oldest parked one. The sender stays the same.
+func (p *Gopactor) Replace(pid *actor.PID, msg interface{}) error {
+	return p.gate(pid, func(c *catcher.Catcher) error {
+		parked, err := c.Park(0)
+		if err == nil {
+			parked.Replace(msg)
+		}
+		return err
+	})
+}
+
+// ReleaseAll lets through the messages parked by all gated actors right now,
+// in order of parking for every actor. It does not wait, and returns
+// the number of released messages.
+func (p *Gopactor) ReleaseAll() int {
+	released := 0
+	for _, c := range p.catchers() {
+		for parked := c.Parked(); parked != nil; parked = c.Parked() {
+			parked.Release()
+			released++
+		}
+	}
+
+	return released
+}
+
+func (p *Gopactor) gate(pid *actor.PID, decide func(*catcher.Catcher) error) error {
+	if pid == nil {
+		return &catcher.Failure{Reason: "Object is not an actor PID"}
+	}
+
+	c := p.getCatcherByPID(pid)
+	if c == nil {
+		return &catcher.Failure{Reason: "Actor is not registered in Gopactor: " + pid.String()}
+	}
+
+	return decide(c)
+}
@@ -1,6 +1,8 @@
 package gopactor
 
 import (
+	"sync"
+
 	"github.com/AsynkronIT/protoactor-go/actor"
 	"github.com/meamidos/gopactor/catcher"
 )
@@ -8,8 +10,16 @@ import (
 // Gopactor represents a group catchers.
 // Each catcher is identified by the PID of the actor
 // the catcher followes.
+// It is safe to spawn actors and write assertions
+// from multiple goroutines at the same time.
 type Gopactor struct {
+	// Guards CatchersByPID and registered
+	mu sync.RWMutex
+
 	CatchersByPID map[string]*catcher.Catcher
+
+	// Catchers in order of registration
+	registered []*catcher.Catcher
 }
 
 // New creates a new instance of Gopactor
@@ -21,70 +31,49 @@ func New() *Gopactor {
 
 // Resets cleans up the Gopactor instance
 func (p *Gopactor) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	p.CatchersByPID = make(map[string]*catcher.Catcher)
+	p.registered = nil
 }
 
 func (p *Gopactor) getCatcherByPID(pid *actor.PID) *catcher.Catcher {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 	return p.CatchersByPID[pid.String()]
 }
 
-func (p *Gopactor) shouldReceive(receiver, sender *actor.PID, msg interface{}) string {
-	catcher := p.getCatcherByPID(receiver)
-	if catcher == nil {
-		return "Receiver is not registered in Gopactor"
-	}
-
-	return catcher.ShouldReceive(sender, msg)
-}
-
-func (p *Gopactor) shouldReceiveSysMsg(receiver *actor.PID, msg interface{}) string {
-	catcher := p.getCatcherByPID(receiver)
-	if catcher == nil {
-		return "Receiver is not registered in Gopactor"
-	}
-
-	return catcher.ShouldReceiveSysMsg(msg)
-}
-
-func (p *Gopactor) shouldStart(pid *actor.PID) string {
-	return p.shouldReceiveSysMsg(pid, &actor.Started{})
+// Lookup returns the catcher following a given actor, if any.
+// It is used by catchers to hand over system messages to each other.
+func (p *Gopactor) Lookup(pid *actor.PID) *catcher.Catcher {
+	return p.getCatcherByPID(pid)
 }
 
-func (p *Gopactor) shouldStop(pid *actor.PID) string {
-	return p.shouldReceiveSysMsg(pid, &actor.Stopped{})
-}
-
-func (p *Gopactor) shouldBeRestarting(pid *actor.PID) string {
-	return p.shouldReceiveSysMsg(pid, &actor.Restarting{})
-}
-
-func (p *Gopactor) shouldObserveTermination(object, pid *actor.PID) string {
-	return p.shouldReceiveSysMsg(object, &actor.Terminated{Who: pid})
-}
-
-func (p *Gopactor) shouldSend(sender, receiver *actor.PID, msg interface{}) string {
-	catcher := p.getCatcherByPID(sender)
-	if catcher == nil {
-		return "Sender is not registered in Gopactor"
+// Register adds a catcher following a given actor.
+// It is used by catchers to register children with recursive interception.
+func (p *Gopactor) Register(pid *actor.PID, catcher *catcher.Catcher) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if previous, ok := p.CatchersByPID[pid.String()]; ok {
+		for i, c := range p.registered {
+			if c == previous {
+				p.registered = append(p.registered[:i], p.registered[i+1:]...)
+				break
+			}
+		}
 	}
 
-	return catcher.ShouldSend(receiver, msg)
+	p.CatchersByPID[pid.String()] = catcher
+	p.registered = append(p.registered, catcher)
 }
 
-func (p *Gopactor) shouldNotSendOrReceive(pid *actor.PID) string {
-	catcher := p.getCatcherByPID(pid)
-	if catcher == nil {
-		return "Sender is not registered in Gopactor"
-	}
-
-	return catcher.ShouldNotSendOrReceive(pid)
-}
-
-func (p *Gopactor) shouldSpawn(parent *actor.PID, match string) string {
-	catcher := p.getCatcherByPID(parent)
-	if catcher == nil {
-		return "Object is not registered in Gopactor"
-	}
+// catchers returns all catchers in order of registration
+func (p *Gopactor) catchers() []*catcher.Catcher {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 
-	return catcher.ShouldSpawn(match)
+	catchers := make([]*catcher.Catcher, len(p.registered))
+	copy(catchers, p.registered)
+	return catchers
 }
@@ -0,0 +1,110 @@
+package gopactor
+
+import (
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type goroutine struct {
+	id      uint64
+	parent  uint64
+	creator string
+	stack   string
+}
+
+// goroutines returns all goroutines with their stacks.
+// The parent is the goroutine which has started it, if the runtime tells (since Go 1.21).
+func goroutines() []goroutine {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	all := []goroutine{}
+	for _, stack := range strings.Split(string(buf), "\n\n") {
+		fields := strings.Fields(stack)
+		if len(fields) < 2 || fields[0] != "goroutine" {
+			continue
+		}
+
+		id, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		g := goroutine{id: id, stack: stack}
+		if i := strings.LastIndex(stack, "\ncreated by "); i >= 0 {
+			line := stack[i+len("\ncreated by "):]
+			if j := strings.Index(line, "\n"); j >= 0 {
+				line = line[:j]
+			}
+
+			g.creator = line
+			if j := strings.Index(line, " in goroutine "); j >= 0 {
+				g.creator = line[:j]
+				g.parent, _ = strconv.ParseUint(line[j+len(" in goroutine "):], 10, 64)
+			}
+		}
+
+		all = append(all, g)
+	}
+
+	return all
+}
+
+// leakedGoroutines waits until the goroutines started by the actors are gone,
+// and returns the stacks of the ones still running when the timeout expires.
+// Goroutines started by those goroutines are counted as well.
+// The actors are given by the goroutines which have run them.
+func leakedGoroutines(actors []uint64, timeout time.Duration) []string {
+	owners := make(map[uint64]bool, len(actors))
+	for _, id := range actors {
+		owners[id] = true
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		leaked := startedBy(owners, goroutines())
+		if len(leaked) == 0 || time.Now().After(deadline) {
+			sort.Strings(leaked)
+			return leaked
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// startedBy returns the stacks of the goroutines started by the owners,
+// directly or through other goroutines. Such goroutines become owners too,
+// so their descendants are recognized even after they have exited.
+// A goroutine whose parent has exited before it was seen can not be traced.
+// Goroutines started by Protoactor, e.g. mailboxes of other actors, are not counted.
+func startedBy(owners map[uint64]bool, all []goroutine) []string {
+	leaked := []string{}
+	found := map[uint64]bool{}
+
+	for more := true; more; {
+		more = false
+		for _, g := range all {
+			if found[g.id] || !owners[g.parent] || strings.Contains(g.creator, "/protoactor-go/") {
+				continue
+			}
+
+			found[g.id] = true
+			owners[g.id] = true
+			leaked = append(leaked, g.stack)
+			more = true
+		}
+	}
+
+	return leaked
+}
@@ -0,0 +1,187 @@
+package gopactor
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/catcher"
+	"github.com/meamidos/gopactor/matchers"
+	"github.com/meamidos/gopactor/options"
+)
+
+// Probe is a spawned actor which records every message it receives,
+// so that the test can await them. It can also send messages on behalf
+// of the test, which makes it a convenient sparring partner for the tested actor.
+type Probe struct {
+	pid     *actor.PID
+	catcher *catcher.Catcher
+	timeout time.Duration
+
+	// Guards lastSender
+	mu         sync.Mutex
+	lastSender *actor.PID
+}
+
+// NewProbe spawns a new probe. Only the prefix and the timeout are taken
+// from the options: a probe always records inbound messages into a journal.
+// If they are not set, the prefix "probe" and the default timeout are used.
+func (p *Gopactor) NewProbe(opts ...options.Options) (*Probe, error) {
+	opt := options.OptDefault.WithPrefix("probe")
+	if len(opts) > 0 {
+		if opts[0].Prefix != "" {
+			opt.Prefix = opts[0].Prefix
+		}
+		if opts[0].Timeout > 0 {
+			opt.Timeout = opts[0].Timeout
+		}
+	}
+
+	probeOpt := options.OptNoInterception.
+		WithInboundInterception().
+		WithJournaling().
+		WithPrefix(opt.Prefix).
+		WithTimeout(opt.Timeout)
+
+	pid, catcher, err := p.spawnWithCatcher(actor.FromInstance(&catcher.NullReceiver{}), probeOpt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Probe{
+		pid:     pid,
+		catcher: catcher,
+		timeout: opt.Timeout,
+	}, nil
+}
+
+// PID returns the PID of the probe actor.
+func (probe *Probe) PID() *actor.PID {
+	return probe.pid
+}
+
+// Tell sends a message to the target on behalf of the probe.
+// The probe is recorded as the sender, so the target can respond to it.
+func (probe *Probe) Tell(target *actor.PID, msg interface{}) {
+	target.Request(msg, probe.pid)
+}
+
+// Reply sends a message to the sender of the last message received by the probe.
+func (probe *Probe) Reply(msg interface{}) error {
+	sender := probe.LastSender()
+	if sender == nil {
+		return fmt.Errorf("The last received message has no sender to reply to")
+	}
+
+	probe.Tell(sender, msg)
+	return nil
+}
+
+// LastSender returns the sender of the last message received by the probe.
+func (probe *Probe) LastSender() *actor.PID {
+	probe.mu.Lock()
+	defer probe.mu.Unlock()
+	return probe.lastSender
+}
+
+// ExpectMsg waits for the next message and checks that it matches the expected one.
+// The expected message can be a matcher.
+func (probe *Probe) ExpectMsg(expected interface{}) error {
+	msg, err := probe.receive(probe.timeout)
+	if err != nil {
+		return err
+	}
+
+	if !matchers.Match(msg, expected) {
+		return fmt.Errorf(`
+Messages do not match
+Expected: %s
+Actual: %#v
+`, matchers.Describe(expected), msg)
+	}
+
+	return nil
+}
+
+// ExpectMsgLike waits for the next message and checks that it is
+// of the same type as the sample. The message is returned.
+// With Go 1.18 or later, ExpectMsgType returns it typed.
+func (probe *Probe) ExpectMsgLike(sample interface{}) (interface{}, error) {
+	msg, err := probe.receive(probe.timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if reflect.TypeOf(msg) != reflect.TypeOf(sample) {
+		return msg, fmt.Errorf(`
+Message type does not match
+Expected: %T
+Actual: %T
+`, sample, msg)
+	}
+
+	return msg, nil
+}
+
+// ExpectNoMsg checks that no message is received during a given period of time.
+func (probe *Probe) ExpectNoMsg(d time.Duration) error {
+	msg, err := probe.receive(d)
+	if err == nil {
+		return fmt.Errorf("Got unexpected message: %#v", msg)
+	}
+
+	return nil
+}
+
+// ReceiveN waits for N messages of any kind and returns them in order.
+func (probe *Probe) ReceiveN(n int) ([]interface{}, error) {
+	messages := make([]interface{}, 0, n)
+	for i := 0; i < n; i++ {
+		msg, err := probe.receive(probe.timeout)
+		if err != nil {
+			return messages, fmt.Errorf("Expected %d messages, but got %d", n, i)
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
+
+// FishForMessage skips messages until the predicate accepts one,
+// which is then returned. The whole search is limited by the timeout.
+func (probe *Probe) FishForMessage(predicate func(msg interface{}) bool) (interface{}, error) {
+	deadline := time.Now().Add(probe.timeout)
+
+	for {
+		timeout := deadline.Sub(time.Now())
+		if timeout <= 0 {
+			break
+		}
+
+		msg, err := probe.receive(timeout)
+		if err != nil {
+			break
+		}
+
+		if predicate(msg) {
+			return msg, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Timeout %s while fishing for a message", probe.timeout)
+}
+
+func (probe *Probe) receive(timeout time.Duration) (interface{}, error) {
+	envelope, ok := probe.catcher.Next(catcher.KindUserInbound, timeout)
+	if !ok {
+		return nil, fmt.Errorf("Timeout %s while waiting for a message", timeout)
+	}
+
+	probe.mu.Lock()
+	probe.lastSender = envelope.Sender
+	probe.mu.Unlock()
+
+	return envelope.Message, nil
+}
@@ -0,0 +1,32 @@
+//go:build go1.18
+// +build go1.18
+
+package gopactor
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ExpectMsgType waits for the next message received by the probe
+// and checks that it is of type T. The message is returned typed:
+//   pong, err := ExpectMsgType[*Pong](probe)
+func ExpectMsgType[T any](probe *Probe) (T, error) {
+	var typed T
+
+	msg, err := probe.receive(probe.timeout)
+	if err != nil {
+		return typed, err
+	}
+
+	typed, ok := msg.(T)
+	if !ok {
+		return typed, fmt.Errorf(`
+Message type does not match
+Expected: %v
+Actual: %T
+`, reflect.TypeOf((*T)(nil)).Elem(), msg)
+	}
+
+	return typed, nil
+}
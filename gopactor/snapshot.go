@@ -0,0 +1,67 @@
+package gopactor
+
+import (
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/catcher"
+	"github.com/meamidos/gopactor/snapshot"
+	"github.com/meamidos/gopactor/trace"
+)
+
+// Conversation returns the conversation recorded by the catchers
+// of the given actors, in order.
+func (p *Gopactor) Conversation(pids ...*actor.PID) (*trace.Trace, error) {
+	histories := make([][]*catcher.Entry, 0, len(pids))
+	for _, pid := range pids {
+		c := p.getCatcherByPID(pid)
+		if c == nil {
+			return nil, &catcher.Failure{Reason: "Actor is not registered in Gopactor: " + pid.String()}
+		}
+		histories = append(histories, c.History())
+	}
+
+	return trace.New(histories...), nil
+}
+
+// ShouldMatchConversationSnapshot is an assertion method. Its rules are:
+// - The conversation recorded by one actor or a list of actors should match
+//   the golden file with a given name under testdata/.
+// - Optional parameters: names of fields to redact in all messages.
+// - With GOPACTOR_UPDATE=1, or the -update flag of the tests, the golden file is written instead.
+func (p *Gopactor) ShouldMatchConversationSnapshot(param1 interface{}, params ...interface{}) string {
+	var pids []*actor.PID
+	switch object := param1.(type) {
+	case *actor.PID:
+		pids = []*actor.PID{object}
+	case []*actor.PID:
+		pids = object
+	}
+
+	if len(pids) == 0 {
+		return "Object is not an actor PID or a list of actor PIDs"
+	}
+
+	if len(params) < 1 {
+		return "One parameter with a snapshot name is required"
+	}
+
+	name, ok := params[0].(string)
+	if !ok {
+		return "Snapshot name should be a string"
+	}
+
+	redacted := make([]string, 0, len(params)-1)
+	for _, param := range params[1:] {
+		field, ok := param.(string)
+		if !ok {
+			return "Redacted fields should be strings"
+		}
+		redacted = append(redacted, field)
+	}
+
+	conversation, err := p.Conversation(pids...)
+	if err != nil {
+		return catcher.FailureMessage(err)
+	}
+
+	return catcher.FailureMessage(snapshot.Match(name, snapshot.Render(conversation, redacted...)))
+}
@@ -7,16 +7,22 @@ import (
 )
 
 func (p *Gopactor) spawn(props *actor.Props, opts ...options.Options) (*actor.PID, error) {
+	pid, _, err := p.spawnWithCatcher(props, opts...)
+	return pid, err
+}
+
+func (p *Gopactor) spawnWithCatcher(props *actor.Props, opts ...options.Options) (*actor.PID, *catcher.Catcher, error) {
 	catcher := catcher.New()
+	catcher.Registry = p
 
 	pid, err := catcher.Spawn(props, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	p.CatchersByPID[pid.String()] = catcher
+	p.Register(pid, catcher)
 
-	return pid, nil
+	return pid, catcher, nil
 }
 
 func (p *Gopactor) SpawnFromInstance(obj actor.Actor, opts ...options.Options) (*actor.PID, error) {
@@ -0,0 +1,59 @@
+package gopactor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/meamidos/gopactor/catcher"
+	"github.com/meamidos/gopactor/trace"
+)
+
+// TRACE_DIR is the directory where ForTest writes sequence diagrams
+// of failed tests. If it is empty, the temporary directory is used.
+var TRACE_DIR = ""
+
+// Trace returns the conversation among all actors followed by the instance,
+// including intercepted children, in order.
+func (p *Gopactor) Trace() *trace.Trace {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	histories := make([][]*catcher.Entry, 0, len(p.CatchersByPID))
+	for _, c := range p.CatchersByPID {
+		histories = append(histories, c.History())
+	}
+
+	return trace.New(histories...)
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// writeTrace saves the trace as PlantUML and Mermaid diagrams
+// named after the test and returns the paths of the files.
+func writeTrace(t *trace.Trace, name string) ([]string, error) {
+	dir := TRACE_DIR
+	if dir == "" {
+		dir = os.TempDir()
+	}
+
+	base := filepath.Join(dir, unsafeFileChars.ReplaceAllString(name, "_"))
+	diagrams := []struct {
+		path    string
+		content string
+	}{
+		{base + ".puml", t.PlantUML()},
+		{base + ".mmd", t.Mermaid()},
+	}
+
+	paths := []string{}
+	for _, diagram := range diagrams {
+		if err := ioutil.WriteFile(diagram.path, []byte(diagram.content), 0644); err != nil {
+			return paths, err
+		}
+		paths = append(paths, diagram.path)
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,15 @@
+package gopactor
+
+import "github.com/meamidos/gopactor/matchers"
+
+// Matchers can be used instead of exact messages in assertions.
+// They are explained in detail in the documentation
+// for the matchers package: https://godoc.org/github.com/meAmidos/gopactor/matchers
+var (
+	MatchType   = matchers.OfType
+	MatchFunc   = matchers.Func
+	MatchRegexp = matchers.Regexp
+	MatchProto  = matchers.ProtoEqual
+	MatchFields = matchers.Fields
+	MatchLike   = matchers.Like
+)
@@ -0,0 +1,243 @@
+// Package matchers provides flexible ways to describe an expected message.
+// Any value implementing the Matcher interface can be used in assertions
+// instead of an exact message. This is handy when messages contain
+// timestamps, generated IDs and other values unknown in advance.
+//
+// Example:
+//
+//   // Any message of the given type will do
+//   So(worker, ShouldReceive, matchers.OfType(&Job{}))
+//
+//   // Only the fields that matter are compared
+//   So(worker, ShouldSend, matchers.Like(&JobDone{Status: "ok"}))
+//   So(worker, ShouldSend, matchers.Fields(map[string]interface{}{
+//       "Status":  "ok",
+//       "Retries": 0,
+//   }))
+//
+//   // Anything else can be expressed with a predicate
+//   So(worker, ShouldSend, matchers.Func(func(msg interface{}) bool {
+//       done, ok := msg.(*JobDone)
+//       return ok && done.Duration < time.Second
+//   }))
+package matchers
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// Matcher decides whether an actual message is the expected one.
+// The string representation is used in assertion failure reports.
+type Matcher interface {
+	Match(actual interface{}) bool
+	String() string
+}
+
+// Match tells whether an actual message matches the expected one.
+// If the expected value is a Matcher, the decision is delegated to it.
+// Otherwise, messages are compared with reflect.DeepEqual.
+func Match(actual, expected interface{}) bool {
+	if matcher, ok := expected.(Matcher); ok {
+		return matcher.Match(actual)
+	}
+
+	return reflect.DeepEqual(actual, expected)
+}
+
+// Describe returns a human-readable representation of an expected value
+// to be used in assertion failure reports.
+func Describe(expected interface{}) string {
+	if matcher, ok := expected.(Matcher); ok {
+		return matcher.String()
+	}
+
+	return fmt.Sprintf("%#v", expected)
+}
+
+// OfType matches any message of the same type as the sample.
+func OfType(sample interface{}) Matcher {
+	return &typeMatcher{reflect.TypeOf(sample)}
+}
+
+type typeMatcher struct {
+	typ reflect.Type
+}
+
+func (m *typeMatcher) Match(actual interface{}) bool {
+	return reflect.TypeOf(actual) == m.typ
+}
+
+func (m *typeMatcher) String() string {
+	return fmt.Sprintf("any message of type %v", m.typ)
+}
+
+// Func matches any message for which the predicate returns true.
+func Func(predicate func(msg interface{}) bool) Matcher {
+	return &funcMatcher{predicate}
+}
+
+type funcMatcher struct {
+	predicate func(msg interface{}) bool
+}
+
+func (m *funcMatcher) Match(actual interface{}) bool {
+	return m.predicate(actual)
+}
+
+func (m *funcMatcher) String() string {
+	return "a message accepted by the predicate"
+}
+
+// Regexp matches any message whose string form matches the regular expression.
+// The string form is produced with fmt.Sprint, so the String() method
+// of a message is respected. It panics if the expression cannot be parsed.
+func Regexp(expr string) Matcher {
+	return &regexpMatcher{regexp.MustCompile(expr)}
+}
+
+type regexpMatcher struct {
+	re *regexp.Regexp
+}
+
+func (m *regexpMatcher) Match(actual interface{}) bool {
+	return m.re.MatchString(fmt.Sprint(actual))
+}
+
+func (m *regexpMatcher) String() string {
+	return fmt.Sprintf("a message matching /%s/", m.re)
+}
+
+// ProtoEqual matches any protobuf message equal to the expected one
+// in terms of proto.Equal.
+func ProtoEqual(expected proto.Message) Matcher {
+	return &protoMatcher{expected}
+}
+
+type protoMatcher struct {
+	expected proto.Message
+}
+
+func (m *protoMatcher) Match(actual interface{}) bool {
+	msg, ok := actual.(proto.Message)
+	if !ok {
+		return false
+	}
+
+	return proto.Equal(msg, m.expected)
+}
+
+func (m *protoMatcher) String() string {
+	return fmt.Sprintf("a protobuf message equal to %T{%v}", m.expected, m.expected)
+}
+
+// Fields matches any struct (or a pointer to struct) which has all the given
+// exported fields set to the given values. Other fields are ignored.
+// A value can be a Matcher itself.
+func Fields(fields map[string]interface{}) Matcher {
+	return &fieldsMatcher{fields}
+}
+
+type fieldsMatcher struct {
+	fields map[string]interface{}
+}
+
+func (m *fieldsMatcher) Match(actual interface{}) bool {
+	value, ok := structValue(actual)
+	if !ok {
+		return false
+	}
+
+	for name, expected := range m.fields {
+		field := value.FieldByName(name)
+		if !field.IsValid() || !field.CanInterface() {
+			return false
+		}
+
+		if !Match(field.Interface(), expected) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m *fieldsMatcher) String() string {
+	names := make([]string, 0, len(m.fields))
+	for name := range m.fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	descriptions := make([]string, 0, len(names))
+	for _, name := range names {
+		descriptions = append(descriptions, fmt.Sprintf("%s: %s", name, Describe(m.fields[name])))
+	}
+
+	return fmt.Sprintf("a message with fields {%s}", strings.Join(descriptions, ", "))
+}
+
+// Like matches any message of the same type as the sample
+// whose fields are equal to all non-zero fields of the sample.
+// Zero fields of the sample are ignored.
+func Like(sample interface{}) Matcher {
+	return &likeMatcher{sample}
+}
+
+type likeMatcher struct {
+	sample interface{}
+}
+
+func (m *likeMatcher) Match(actual interface{}) bool {
+	if reflect.TypeOf(actual) != reflect.TypeOf(m.sample) {
+		return false
+	}
+
+	expected, ok := structValue(m.sample)
+	if !ok {
+		return reflect.DeepEqual(actual, m.sample)
+	}
+
+	value, ok := structValue(actual)
+	if !ok {
+		return false
+	}
+
+	for i := 0; i < expected.NumField(); i++ {
+		field := expected.Field(i)
+		if !field.CanInterface() || isZero(field) {
+			continue
+		}
+
+		if !reflect.DeepEqual(value.Field(i).Interface(), field.Interface()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m *likeMatcher) String() string {
+	return fmt.Sprintf("a message like %#v", m.sample)
+}
+
+func structValue(obj interface{}) (reflect.Value, bool) {
+	value := reflect.ValueOf(obj)
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return value, false
+		}
+		value = value.Elem()
+	}
+
+	return value, value.Kind() == reflect.Struct
+}
+
+func isZero(value reflect.Value) bool {
+	return reflect.DeepEqual(value.Interface(), reflect.Zero(value.Type()).Interface())
+}
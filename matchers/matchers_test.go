@@ -0,0 +1,121 @@
+package matchers_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/meamidos/gopactor/matchers"
+	"github.com/stretchr/testify/assert"
+)
+
+type Event struct {
+	ID        string
+	Name      string
+	Count     int
+	Timestamp time.Time
+}
+
+// A tiny hand-written protobuf message
+type Ping struct {
+	Payload string `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (m *Ping) Reset()         { *m = Ping{} }
+func (m *Ping) String() string { return "payload:" + m.Payload }
+func (*Ping) ProtoMessage()    {}
+
+func TestMatch(t *testing.T) {
+	a := assert.New(t)
+
+	// Plain values are compared as is
+	a.True(matchers.Match("ping", "ping"))
+	a.False(matchers.Match("ping", "pong"))
+	a.True(matchers.Match(&Event{Name: "a"}, &Event{Name: "a"}))
+	a.False(matchers.Match(&Event{Name: "a", ID: "1"}, &Event{Name: "a"}))
+
+	// Matchers decide for themselves
+	a.True(matchers.Match(&Event{Name: "a", ID: "1"}, matchers.OfType(&Event{})))
+}
+
+func TestOfType(t *testing.T) {
+	a := assert.New(t)
+
+	m := matchers.OfType(&Event{})
+	a.True(m.Match(&Event{ID: "42"}))
+	a.False(m.Match(Event{ID: "42"}))
+	a.False(m.Match("event"))
+	a.False(m.Match(nil))
+	a.Contains(m.String(), "Event")
+}
+
+func TestFunc(t *testing.T) {
+	a := assert.New(t)
+
+	m := matchers.Func(func(msg interface{}) bool {
+		event, ok := msg.(*Event)
+		return ok && event.Count > 1
+	})
+	a.True(m.Match(&Event{Count: 2}))
+	a.False(m.Match(&Event{Count: 1}))
+	a.False(m.Match(2))
+}
+
+func TestRegexp(t *testing.T) {
+	a := assert.New(t)
+
+	m := matchers.Regexp(`^user-\d+$`)
+	a.True(m.Match("user-123"))
+	a.False(m.Match("user-abc"))
+
+	// The String() method of a message is respected
+	a.True(matchers.Regexp(`payload:hel+o`).Match(&Ping{Payload: "hello"}))
+
+	a.Panics(func() { matchers.Regexp(`(`) })
+}
+
+func TestProtoEqual(t *testing.T) {
+	a := assert.New(t)
+
+	m := matchers.ProtoEqual(&Ping{Payload: "hello"})
+	a.True(m.Match(&Ping{Payload: "hello"}))
+	a.False(m.Match(&Ping{Payload: "bye"}))
+	a.False(m.Match("hello"))
+}
+
+func TestFields(t *testing.T) {
+	a := assert.New(t)
+
+	m := matchers.Fields(map[string]interface{}{
+		"Name":  "created",
+		"Count": 0,
+		"ID":    matchers.Regexp(`^id-`),
+	})
+	a.True(m.Match(&Event{ID: "id-1", Name: "created", Timestamp: time.Now()}))
+	a.True(m.Match(Event{ID: "id-2", Name: "created"}))
+	a.False(m.Match(&Event{ID: "id-1", Name: "created", Count: 1}))
+	a.False(m.Match(&Event{ID: "1", Name: "created"}))
+	a.False(m.Match("created"))
+	a.False(m.Match((*Event)(nil)))
+
+	// Unknown fields never match
+	a.False(matchers.Fields(map[string]interface{}{"Foo": 1}).Match(&Event{}))
+
+	// The description is stable
+	a.True(strings.Index(m.String(), "Count") < strings.Index(m.String(), "ID"))
+	a.True(strings.Index(m.String(), "ID") < strings.Index(m.String(), "Name"))
+}
+
+func TestLike(t *testing.T) {
+	a := assert.New(t)
+
+	m := matchers.Like(&Event{Name: "created"})
+	a.True(m.Match(&Event{ID: "1", Name: "created", Timestamp: time.Now()}))
+	a.False(m.Match(&Event{ID: "1", Name: "deleted"}))
+	a.False(m.Match(Event{Name: "created"}))
+	a.False(m.Match((*Event)(nil)))
+
+	// Non-struct samples are compared as is
+	a.True(matchers.Like("ping").Match("ping"))
+	a.False(matchers.Like("ping").Match("pong"))
+}
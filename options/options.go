@@ -30,9 +30,38 @@
 //   // - Do not spawn dummy no-op children actors
 //   opt4 := OptNoInterception.WithSpawnInterception()
 //   actor4, _ := SpawnFromInstance(&MyActor{}, opt4)
+//
+//   // Do not block the actor:
+//   // - Record intercepted messages into a journal and let the actor run freely
+//   // - Assertions consume the recorded messages in order
+//   opt5 := OptDefault.WithJournaling()
+//   actor5, _ := SpawnFromInstance(&MyActor{}, opt5)
+//
+//   // Ignore the noise:
+//   // - Heartbeats pass through without interception and never block the actor
+//   opt6 := OptDefault.WithoutMessageTypes(&Heartbeat{})
+//   actor6, _ := SpawnFromInstance(&MyActor{}, opt6)
+//
+//   // Test retries:
+//   // - Every second request sent by the actor is lost
+//   opt7 := OptDefault.WithOutboundFault(Fault{Action: FaultDrop, Every: 2,
+//       Filter: Filter{MessageType: reflect.TypeOf(&Request{})}})
+//   actor7, _ := SpawnFromInstance(&MyActor{}, opt7)
+//
+//   // Test expiry without waiting:
+//   // - Receive timeouts fire when the test advances the virtual clock
+//   clk := clock.NewVirtual(time.Now())
+//   opt8 := OptDefault.WithSystemInterception().WithClock(clk)
+//   actor8, _ := SpawnFromInstance(&MyActor{}, opt8)
 package options
 
-import "time"
+import (
+	"reflect"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/clock"
+)
 
 // DEFAULT_TIMEOUT value is used when no custom timeout has been specified.
 // Three milliseconds is long enough to allow for some regular operations
@@ -50,10 +79,77 @@ type Options struct {
 	OutboundInterceptionEnabled bool
 	SystemInterceptionEnabled   bool
 
+	// System messages sent by the actor: Stop, Watch, Unwatch and PoisonPill.
+	// Note that pid.Stop() and pid.Tell() bypass the actor context and cannot
+	// be intercepted: the stopped actor can be asserted to stop instead.
+	// A PoisonPill sent with ctx.Tell() is intercepted. Children stopped by
+	// the supervisor are intercepted with supervision recording, or if
+	// the children are followed, e.g. with recursive interception.
+	OutboundSystemInterceptionEnabled bool
+
 	// Spawning
 	SpawnInterceptionEnabled bool
 	DummySpawningEnabled     bool
 
+	// By default, an intercepted actor is blocked until the intercepted
+	// message is consumed by an assertion. System messages and supervision
+	// decisions never block, they are queued until asserted. With journaling,
+	// every intercepted envelope is recorded into an unbounded ordered journal
+	// instead, and the actor keeps running. Assertions consume from the journal.
+	JournalingEnabled bool
+
+	// With gating, every user message delivered to the actor is parked
+	// before it is intercepted, until the test releases, drops or replaces it.
+	// It gives exact control over the interleaving of several actors.
+	GatingEnabled bool
+
+	// Supervision of children.
+	// The strategy is used to supervise children of the actor. If it is not set,
+	// the default strategy of Protoactor is used. With recording enabled,
+	// every decision made by the strategy can be asserted. A strategy given
+	// in the props of the actor is kept unless supervision is recorded, but then
+	// a followed child resumed after a failure and stopped later is taken for
+	// a child stopped by the supervisor. With system interception,
+	// failures of children are intercepted as Failure messages received
+	// by the actor if supervision is recorded, or if the children are followed.
+	SupervisionRecordingEnabled bool
+	SupervisorStrategy          actor.SupervisorStrategy
+
+	// Children matching any of the substitutions are replaced with stubs
+	// when spawned. Substitutions by name are checked before substitutions
+	// by actor type, otherwise the first matching substitution wins.
+	// Substitutions take precedence over dummy spawning.
+	Substitutions []Substitution
+
+	// With recursive interception, every child spawned by the actor
+	// is followed by its own catcher with the same options, and so on,
+	// until the depth limit is reached. Depth 1 means children only,
+	// depth 2 means children and grandchildren. It is mostly useful
+	// together with real spawning.
+	RecursiveInterceptionDepth int
+
+	// Filters narrow down which user messages are intercepted, inbound
+	// or outbound. A message is intercepted if it matches any of the includes
+	// (or there are no includes) and none of the excludes. Other messages pass
+	// through untouched and never block the actor. System messages are
+	// not filtered.
+	Includes []Filter
+	Excludes []Filter
+
+	// Fault rules disturb the delivery of user messages to and from the actor,
+	// so that retries, timeouts and idempotency can be tested. Faults apply
+	// between the actor and the rest of the system: an outbound message is
+	// intercepted once as it is sent, an inbound message is intercepted
+	// every time it is actually delivered. The first rule which applies
+	// to the message wins: a rule skipping the message because of Every
+	// leaves it to the next rules. Every applied fault is logged.
+	Faults []Fault
+
+	// With a clock, receive timeouts set by the actor are scheduled on it
+	// instead of the real time, e.g. on a virtual clock advanced by the test.
+	// Timeouts of assertions are not affected.
+	Clock clock.Clock
+
 	// A prefix of the spawned actor name.
 	// It is useful mostly in cases when you debug your application
 	// and want the actor's PID to have a meaningful value.
@@ -64,6 +160,120 @@ type Options struct {
 	Timeout time.Duration
 }
 
+// Substitution replaces a child actor with a stub when the child is spawned.
+// A child is matched either by its name or by the type of its actor.
+type Substitution struct {
+	// A pattern in terms of path.Match, e.g. "db-writer-*".
+	// It is matched against the prefix of a child spawned with SpawnPrefix
+	// and against the name of a child spawned with SpawnNamed.
+	Pattern string
+
+	// The type of the actor produced by the child props.
+	// Note that all actors spawned with actor.FromFunc share the same type.
+	ActorType reflect.Type
+
+	// The stub receives all messages instead of the original child.
+	// The same stub instance serves all matching children.
+	Stub actor.Actor
+}
+
+// Filter matches messages by their type, by a predicate, by the sender
+// and by the target. Only the conditions which are set are checked,
+// and all of them should hold. An empty filter matches everything.
+type Filter struct {
+	// The type of the message, e.g. reflect.TypeOf(&Heartbeat{}).
+	MessageType reflect.Type
+
+	// A custom condition on the message.
+	Predicate func(msg interface{}) bool
+
+	// Note that messages sent with Tell have no sender.
+	Sender *actor.PID
+	Target *actor.PID
+}
+
+// Matches tells whether a message satisfies all conditions of the filter
+func (f Filter) Matches(sender, target *actor.PID, msg interface{}) bool {
+	if f.MessageType != nil && reflect.TypeOf(msg) != f.MessageType {
+		return false
+	}
+
+	if f.Predicate != nil && !f.Predicate(msg) {
+		return false
+	}
+
+	if f.Sender != nil && !f.Sender.Equal(sender) {
+		return false
+	}
+
+	if f.Target != nil && !f.Target.Equal(target) {
+		return false
+	}
+
+	return true
+}
+
+// FaultAction is what a fault rule does to a matching message
+type FaultAction int
+
+const (
+	// The message is lost
+	FaultDrop FaultAction = iota
+
+	// The message is delivered later, other messages may overtake it
+	FaultDelay
+
+	// The message is delivered twice
+	FaultDuplicate
+
+	// The message is held until the next matching message,
+	// and then it is delivered right after it. If there is no next one,
+	// a message sent by the actor is released when the actor stops,
+	// and a message to the actor is dropped.
+	FaultReorder
+)
+
+var faultActionNames = map[FaultAction]string{
+	FaultDrop:      "drop",
+	FaultDelay:     "delay",
+	FaultDuplicate: "duplicate",
+	FaultReorder:   "reorder",
+}
+
+func (action FaultAction) String() string {
+	if name, ok := faultActionNames[action]; ok {
+		return name
+	}
+
+	return "unknown fault"
+}
+
+// Fault is a declarative rule which disturbs the delivery of user messages
+// matching the filter. E.g. drop one in ten heartbeats sent to the monitor:
+//
+//   Fault{Action: FaultDrop, Outbound: true, Every: 10,
+//       Filter: Filter{MessageType: reflect.TypeOf(&Heartbeat{}), Target: monitor}}
+type Fault struct {
+	Action FaultAction
+	Filter Filter
+
+	// Messages sent by the actor instead of messages received by it
+	Outbound bool
+
+	// The fault applies to every Nth matching message only,
+	// other matching messages are left to the next rules.
+	// Zero means every matching message.
+	Every int
+
+	// For FaultDelay only
+	Delay time.Duration
+}
+
+// Applies tells whether the fault applies to the Nth matching message, starting from one
+func (f Fault) Applies(n int) bool {
+	return f.Every <= 1 || n%f.Every == 0
+}
+
 // OptNoInterception is one of predefined configurations:
 // - interception is disabled
 // - no dummy spawning
@@ -117,6 +327,13 @@ func (opt Options) WithSystemInterception() Options {
 	return opt
 }
 
+// WithOutboundSystemInterception is a helper method to add interception
+// of system messages sent by the actor to options
+func (opt Options) WithOutboundSystemInterception() Options {
+	opt.OutboundSystemInterceptionEnabled = true
+	return opt
+}
+
 // WithSpawnInterception is a helper method to add spawning interception to options
 func (opt Options) WithSpawnInterception() Options {
 	opt.SpawnInterceptionEnabled = true
@@ -135,6 +352,164 @@ func (opt Options) WithRealSpawning() Options {
 	return opt
 }
 
+// WithJournaling is a helper method to record intercepted envelopes
+// into a journal without blocking the actor
+func (opt Options) WithJournaling() Options {
+	opt.JournalingEnabled = true
+	return opt
+}
+
+// WithLockStep is a helper method to disable journaling in options,
+// so that the actor waits for every intercepted envelope to be consumed
+func (opt Options) WithLockStep() Options {
+	opt.JournalingEnabled = false
+	return opt
+}
+
+// WithGating is a helper method to park inbound messages
+// until the test lets them through
+func (opt Options) WithGating() Options {
+	opt.GatingEnabled = true
+	return opt
+}
+
+// WithSupervisionRecording is a helper method to add recording of supervisor decisions to options
+func (opt Options) WithSupervisionRecording() Options {
+	opt.SupervisionRecordingEnabled = true
+	return opt
+}
+
+// WithSupervisorStrategy is a helper method to add a supervisor strategy to options
+func (opt Options) WithSupervisorStrategy(strategy actor.SupervisorStrategy) Options {
+	opt.SupervisorStrategy = strategy
+	return opt
+}
+
+// WithStubbedChild is a helper method to replace children whose name
+// matches the pattern with a stub, e.g. WithStubbedChild("db-writer-*", stub)
+func (opt Options) WithStubbedChild(pattern string, stub actor.Actor) Options {
+	opt = opt.clone()
+	opt.Substitutions = append(opt.Substitutions, Substitution{Pattern: pattern, Stub: stub})
+	return opt
+}
+
+// WithStubbedActor is a helper method to replace children whose actor
+// is of the same type as the sample with a stub, e.g. WithStubbedActor(&DBWriter{}, stub)
+func (opt Options) WithStubbedActor(sample actor.Actor, stub actor.Actor) Options {
+	opt = opt.clone()
+	opt.Substitutions = append(opt.Substitutions, Substitution{ActorType: reflect.TypeOf(sample), Stub: stub})
+	return opt
+}
+
+// WithRecursiveInterception is a helper method to intercept descendants
+// of the actor down to the given depth
+func (opt Options) WithRecursiveInterception(depth int) Options {
+	opt.RecursiveInterceptionDepth = depth
+	return opt
+}
+
+// WithMessageTypes is a helper method to intercept only messages
+// of the same types as the samples, e.g. WithMessageTypes(&Request{}, "")
+func (opt Options) WithMessageTypes(samples ...interface{}) Options {
+	for _, sample := range samples {
+		opt = opt.WithIncludeFilter(Filter{MessageType: reflect.TypeOf(sample)})
+	}
+	return opt
+}
+
+// WithoutMessageTypes is a helper method to let messages of the same types
+// as the samples pass without interception, e.g. WithoutMessageTypes(&Heartbeat{})
+func (opt Options) WithoutMessageTypes(samples ...interface{}) Options {
+	for _, sample := range samples {
+		opt = opt.WithExcludeFilter(Filter{MessageType: reflect.TypeOf(sample)})
+	}
+	return opt
+}
+
+// WithMessagesMatching is a helper method to intercept only messages
+// which satisfy the predicate
+func (opt Options) WithMessagesMatching(predicate func(msg interface{}) bool) Options {
+	return opt.WithIncludeFilter(Filter{Predicate: predicate})
+}
+
+// WithoutMessagesMatching is a helper method to let messages which satisfy
+// the predicate pass without interception
+func (opt Options) WithoutMessagesMatching(predicate func(msg interface{}) bool) Options {
+	return opt.WithExcludeFilter(Filter{Predicate: predicate})
+}
+
+// WithIncludeFilter is a helper method to add an include filter to options
+func (opt Options) WithIncludeFilter(filter Filter) Options {
+	opt = opt.clone()
+	opt.Includes = append(opt.Includes, filter)
+	return opt
+}
+
+// WithExcludeFilter is a helper method to add an exclude filter to options
+func (opt Options) WithExcludeFilter(filter Filter) Options {
+	opt = opt.clone()
+	opt.Excludes = append(opt.Excludes, filter)
+	return opt
+}
+
+// WithInboundFault is a helper method to add a fault rule
+// for messages received by the actor
+func (opt Options) WithInboundFault(fault Fault) Options {
+	fault.Outbound = false
+	opt = opt.clone()
+	opt.Faults = append(opt.Faults, fault)
+	return opt
+}
+
+// WithOutboundFault is a helper method to add a fault rule
+// for messages sent by the actor
+func (opt Options) WithOutboundFault(fault Fault) Options {
+	fault.Outbound = true
+	opt = opt.clone()
+	opt.Faults = append(opt.Faults, fault)
+	return opt
+}
+
+// clone copies the slices of the options. Options are passed by value,
+// but appending to a shared slice could overwrite the elements appended
+// to other options derived from the same base.
+func (opt Options) clone() Options {
+	opt.Substitutions = append([]Substitution(nil), opt.Substitutions...)
+	opt.Includes = append([]Filter(nil), opt.Includes...)
+	opt.Excludes = append([]Filter(nil), opt.Excludes...)
+	opt.Faults = append([]Fault(nil), opt.Faults...)
+	return opt
+}
+
+// WithClock is a helper method to schedule receive timeouts of the actor on a given clock
+func (opt Options) WithClock(c clock.Clock) Options {
+	opt.Clock = c
+	return opt
+}
+
+// Intercepts tells whether a user message passes the filters of the options
+func (opt Options) Intercepts(sender, target *actor.PID, msg interface{}) bool {
+	included := len(opt.Includes) == 0
+	for _, filter := range opt.Includes {
+		if filter.Matches(sender, target, msg) {
+			included = true
+			break
+		}
+	}
+
+	if !included {
+		return false
+	}
+
+	for _, filter := range opt.Excludes {
+		if filter.Matches(sender, target, msg) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // WithPrefix is a helper method to add prefix to options
 func (opt Options) WithPrefix(prefix string) Options {
 	opt.Prefix = prefix
@@ -1,13 +1,19 @@
 package options_test
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
+	"github.com/AsynkronIT/protoactor-go/actor"
 	"github.com/meamidos/gopactor/options"
 	"github.com/stretchr/testify/assert"
 )
 
+type Writer struct{}
+
+func (w *Writer) Receive(ctx actor.Context) {}
+
 func TestOptionsWith(t *testing.T) {
 	a := assert.New(t)
 
@@ -20,6 +26,11 @@ func TestOptionsWith(t *testing.T) {
 	a.False(options.SystemInterceptionEnabled)
 	a.False(options.SpawnInterceptionEnabled)
 	a.False(options.DummySpawningEnabled)
+	a.False(options.JournalingEnabled)
+	a.False(options.SupervisionRecordingEnabled)
+	a.Nil(options.SupervisorStrategy)
+	a.Empty(options.Substitutions)
+	a.Equal(0, options.RecursiveInterceptionDepth)
 	a.Empty(options.Prefix)
 	a.Equal(time.Duration(0), options.Timeout)
 
@@ -102,4 +113,116 @@ func TestOptionsWith(t *testing.T) {
 	a.False(options.DummySpawningEnabled)
 	a.Empty(options.Prefix)
 	a.Equal(time.Duration(0), options.Timeout)
+
+	// With journaling
+	options = emptyOptions.WithJournaling()
+	a.False(options.InboundInterceptionEnabled)
+	a.False(options.OutboundInterceptionEnabled)
+	a.False(options.SystemInterceptionEnabled)
+	a.False(options.SpawnInterceptionEnabled)
+	a.False(options.DummySpawningEnabled)
+	a.True(options.JournalingEnabled)
+	a.Empty(options.Prefix)
+	a.Equal(time.Duration(0), options.Timeout)
+
+	// With lock step
+	options = emptyOptions.WithJournaling().WithLockStep()
+	a.False(options.JournalingEnabled)
+
+	// With supervision recording
+	options = emptyOptions.WithSupervisionRecording()
+	a.True(options.SupervisionRecordingEnabled)
+	a.Nil(options.SupervisorStrategy)
+
+	// With supervisor strategy
+	strategy := actor.NewOneForOneStrategy(1, time.Second, actor.DefaultDecider)
+	options = emptyOptions.WithSupervisorStrategy(strategy)
+	a.False(options.SupervisionRecordingEnabled)
+	a.Equal(strategy, options.SupervisorStrategy)
+
+	// With stubbed children
+	stub := actor.ActorFunc(func(ctx actor.Context) {})
+	base := emptyOptions.WithStubbedChild("db-*", stub)
+	options = base.WithStubbedActor(&Writer{}, stub)
+	a.Len(base.Substitutions, 1)
+	a.Equal("db-*", base.Substitutions[0].Pattern)
+	a.Nil(base.Substitutions[0].ActorType)
+	a.Len(options.Substitutions, 2)
+	a.Empty(options.Substitutions[1].Pattern)
+	a.Equal(reflect.TypeOf(&Writer{}), options.Substitutions[1].ActorType)
+
+	// Derived options do not share substitutions
+	other := base.WithStubbedChild("cache-*", stub)
+	a.Equal("db-*", options.Substitutions[0].Pattern)
+	a.Empty(options.Substitutions[1].Pattern)
+	a.Equal("cache-*", other.Substitutions[1].Pattern)
+
+	// With recursive interception
+	options = emptyOptions.WithRecursiveInterception(2)
+	a.Equal(2, options.RecursiveInterceptionDepth)
+	a.False(options.DummySpawningEnabled)
+}
+
+func TestOptionsFilters(t *testing.T) {
+	a := assert.New(t)
+
+	sender := actor.NewLocalPID("sender")
+	target := actor.NewLocalPID("target")
+
+	// No filters: everything is intercepted
+	opt := options.Options{}
+	a.True(opt.Intercepts(nil, target, "hello"))
+
+	// Include by type
+	base := opt.WithMessageTypes("")
+	a.Len(base.Includes, 1)
+	a.True(base.Intercepts(nil, target, "hello"))
+	a.False(base.Intercepts(nil, target, 42))
+
+	// Exclude by predicate on top of the include
+	opt = base.WithoutMessagesMatching(func(msg interface{}) bool { return msg == "tick" })
+	a.True(opt.Intercepts(nil, target, "hello"))
+	a.False(opt.Intercepts(nil, target, "tick"))
+
+	// Derived opt do not share filters
+	a.Empty(base.Excludes)
+	a.Len(opt.Excludes, 1)
+
+	// Exclude by type
+	opt = opt.WithoutMessageTypes(42)
+	a.False(opt.Intercepts(nil, target, 42))
+
+	// Filter by sender and target
+	opt = opt.WithExcludeFilter(options.Filter{Sender: sender, Target: target})
+	a.True(opt.Intercepts(nil, target, "hello"))
+	a.False(opt.Intercepts(sender, target, "hello"))
+	a.True(opt.Intercepts(sender, sender, "hello"))
+
+	// Include by predicate
+	opt = opt.WithMessagesMatching(func(msg interface{}) bool { return msg == 42 })
+	a.Len(opt.Includes, 2)
+}
+
+func TestOptionsFaults(t *testing.T) {
+	a := assert.New(t)
+
+	base := options.Options{}.WithInboundFault(options.Fault{Action: options.FaultDrop, Outbound: true, Every: 3})
+	opt := base.WithOutboundFault(options.Fault{Action: options.FaultDuplicate})
+
+	// The direction is set by the helper
+	a.False(opt.Faults[0].Outbound)
+	a.True(opt.Faults[1].Outbound)
+
+	// Derived options do not share faults
+	a.Len(base.Faults, 1)
+	a.Len(opt.Faults, 2)
+
+	// Every third message
+	a.False(opt.Faults[0].Applies(1))
+	a.False(opt.Faults[0].Applies(2))
+	a.True(opt.Faults[0].Applies(3))
+	a.True(opt.Faults[1].Applies(1))
+
+	a.Equal("drop", options.FaultDrop.String())
+	a.Equal("reorder", options.FaultReorder.String())
 }
@@ -0,0 +1,32 @@
+package gopactor
+
+import (
+	"testing"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+type heartbeat struct{}
+
+func TestInterceptionFilters(t *testing.T) {
+	a := assert.New(t)
+
+	receiver, _ := SpawnNullActor(OptNoInterception)
+	sender, _ := SpawnFromFunc(func(ctx actor.Context) {
+		if ctx.Message() == "go" {
+			ctx.Tell(receiver, &heartbeat{})
+			ctx.Tell(receiver, "done")
+		}
+	}, OptDefault.WithoutMessageTypes(&heartbeat{}))
+
+	// Heartbeats never block the actor and never reach assertions
+	sender.Tell(&heartbeat{})
+	sender.Tell("go")
+	a.Empty(ShouldReceive(sender, "go"))
+	a.Empty(ShouldSend(sender, "done"))
+	a.Empty(ShouldNotSendOrReceive(sender))
+
+	// Cleanup
+	PactReset()
+}
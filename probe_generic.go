@@ -0,0 +1,12 @@
+//go:build go1.18
+// +build go1.18
+
+package gopactor
+
+import "github.com/meamidos/gopactor/gopactor"
+
+// ExpectMsgType waits for the next message received by the probe
+// and returns it typed, if it is of type T.
+func ExpectMsgType[T any](probe *gopactor.Probe) (T, error) {
+	return gopactor.ExpectMsgType[T](probe)
+}
@@ -0,0 +1,40 @@
+//go:build go1.18
+// +build go1.18
+
+package gopactor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProbe_ExpectMsgTypeGeneric(t *testing.T) {
+	a := assert.New(t)
+
+	probe, _ := NewProbe()
+
+	probe.PID().Tell(&probeMsg{})
+	started, err := ExpectMsgType[*probeMsg](probe)
+	a.Nil(err)
+	a.NotNil(started)
+
+	probe.PID().Tell("ping")
+	_, err = ExpectMsgType[*probeMsg](probe)
+	a.Contains(err.Error(), "type does not match")
+	a.Contains(err.Error(), "Expected: *gopactor.probeMsg\n")
+	a.Contains(err.Error(), "Actual: string\n")
+
+	probe.PID().Tell(&probeMsg{})
+	_, err = ExpectMsgType[string](probe)
+	a.Contains(err.Error(), "Expected: string\n")
+
+	// Interfaces are fine too
+	probe.PID().Tell("ping")
+	msg, err := ExpectMsgType[interface{}](probe)
+	a.Nil(err)
+	a.Equal("ping", msg)
+
+	// Cleanup
+	PactReset()
+}
@@ -0,0 +1,137 @@
+package gopactor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/options"
+	"github.com/stretchr/testify/assert"
+)
+
+type probeMsg struct{}
+
+func TestProbe(t *testing.T) {
+	a := assert.New(t)
+
+	probe, err := NewProbe(OptDefault.WithPrefix("probe").WithTimeout(20 * time.Millisecond))
+	a.Nil(err)
+
+	// Failure: Timeout
+	a.Contains(probe.ExpectMsg("ping").Error(), "Timeout")
+
+	// Failure: Message mismatch
+	probe.PID().Tell("ping")
+	a.Contains(probe.ExpectMsg("pong").Error(), "do not match")
+
+	// Success: Message match
+	probe.PID().Tell("ping")
+	a.Nil(probe.ExpectMsg("ping"))
+
+	// Success: Matcher
+	probe.PID().Tell("ping")
+	a.Nil(probe.ExpectMsg(MatchType("")))
+
+	// Expect a type
+	probe.PID().Tell(&probeMsg{})
+	msg, err := probe.ExpectMsgLike(&probeMsg{})
+	a.Nil(err)
+	a.Equal(&probeMsg{}, msg)
+
+	probe.PID().Tell("ping")
+	_, err = probe.ExpectMsgLike(&probeMsg{})
+	a.Contains(err.Error(), "type does not match")
+
+	// Expect no message
+	a.Nil(probe.ExpectNoMsg(10 * time.Millisecond))
+	probe.PID().Tell("ping")
+	a.Contains(probe.ExpectNoMsg(10*time.Millisecond).Error(), "unexpected message")
+
+	// Receive several messages
+	probe.PID().Tell(1)
+	probe.PID().Tell(2)
+	messages, err := probe.ReceiveN(2)
+	a.Nil(err)
+	a.Equal([]interface{}{1, 2}, messages)
+
+	probe.PID().Tell(3)
+	messages, err = probe.ReceiveN(2)
+	a.Contains(err.Error(), "Expected 2 messages, but got 1")
+	a.Equal([]interface{}{3}, messages)
+
+	// Fish for a message
+	probe.PID().Tell(1)
+	probe.PID().Tell("two")
+	probe.PID().Tell(3)
+	msg, err = probe.FishForMessage(func(msg interface{}) bool {
+		_, ok := msg.(string)
+		return ok
+	})
+	a.Nil(err)
+	a.Equal("two", msg)
+	a.Nil(probe.ExpectMsg(3))
+
+	_, err = probe.FishForMessage(func(msg interface{}) bool { return false })
+	a.Contains(err.Error(), "fishing")
+
+	// Cleanup
+	PactReset()
+}
+
+func TestProbe_Defaults(t *testing.T) {
+	a := assert.New(t)
+
+	// Options without a timeout fall back to the default one
+	named, _ := NewProbe(options.Options{Prefix: "named"})
+	a.Contains(named.PID().String(), "named")
+	named.PID().Tell("ping")
+	a.Nil(named.ExpectMsg("ping"))
+
+	// Options without a prefix fall back to "probe"
+	slow, _ := NewProbe(options.Options{Timeout: time.Second})
+	a.Contains(slow.PID().String(), "probe")
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		slow.PID().Tell("late")
+	}()
+	a.Nil(slow.ExpectMsg("late"))
+
+	// Cleanup
+	PactReset()
+}
+
+func TestProbe_TellAndReply(t *testing.T) {
+	a := assert.New(t)
+
+	echo, _ := SpawnFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(string); ok {
+			ctx.Respond("echo: " + msg)
+		}
+	}, OptNoInterception)
+
+	probe, _ := NewProbe()
+	other, _ := NewProbe()
+
+	// Nobody to reply to yet
+	a.NotNil(probe.Reply("hi"))
+
+	// The probe is the sender, so the response comes back to it
+	probe.Tell(echo, "hello")
+	a.Nil(probe.ExpectMsg("echo: hello"))
+
+	// Responses have no sender
+	a.Nil(probe.LastSender())
+
+	// Reply to the last sender
+	other.Tell(probe.PID(), "question")
+	a.Nil(probe.ExpectMsg("question"))
+	a.Nil(probe.Reply("answer"))
+	a.Nil(other.ExpectMsg("answer"))
+
+	// A probe is a regular Gopactor actor, so assertions work for it too
+	probe.Tell(other.PID(), "hey")
+	a.Empty(ShouldReceiveFrom(other.PID(), probe.PID(), "hey"))
+
+	// Cleanup
+	PactReset()
+}
@@ -0,0 +1,22 @@
+package gopactor
+
+import (
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/catcher"
+)
+
+// MsgFrom describes a message expected from a given sender.
+// It is used in assertions about sets of messages:
+//   So(coordinator, ShouldReceiveAll, MsgFrom(worker1, "done"), MsgFrom(worker2, "done"))
+// The message can be a matcher. A nil message means any message.
+func MsgFrom(sender *actor.PID, msg interface{}) *catcher.Envelope {
+	return &catcher.Envelope{Sender: sender, Message: msg}
+}
+
+// MsgTo describes a message expected to be sent to a given receiver.
+// It is used in assertions about sets of messages:
+//   So(coordinator, ShouldSendAll, MsgTo(worker1, "job"), MsgTo(worker2, "job"))
+// The message can be a matcher. A nil message means any message.
+func MsgTo(receiver *actor.PID, msg interface{}) *catcher.Envelope {
+	return &catcher.Envelope{Target: receiver, Message: msg}
+}
@@ -1,28 +1,73 @@
 package gopactor
 
-import "github.com/meamidos/gopactor/assertions"
+import (
+	"github.com/meamidos/gopactor/assertions"
+	"github.com/meamidos/gopactor/gopactor"
+)
 
 // These assertions are mostly self-explanatory,
 // but it may be helpful to go through some examples
 // which can be found in the documentation for the assertions package:
 // https://godoc.org/github.com/meAmidos/gopactor/assertions
 var (
-	ShouldReceive          = assertions.ShouldReceive
-	ShouldReceiveFrom      = assertions.ShouldReceiveFrom
-	ShouldReceiveSomething = assertions.ShouldReceiveSomething
-	ShouldReceiveN         = assertions.ShouldReceiveN
+	ShouldReceive           = assertions.ShouldReceive
+	ShouldReceiveFrom       = assertions.ShouldReceiveFrom
+	ShouldReceiveSomething  = assertions.ShouldReceiveSomething
+	ShouldReceiveN          = assertions.ShouldReceiveN
+	ShouldReceiveAll        = assertions.ShouldReceiveAll
+	ShouldEventuallyReceive = assertions.ShouldEventuallyReceive
 
-	ShouldSend          = assertions.ShouldSend
-	ShouldSendTo        = assertions.ShouldSendTo
-	ShouldSendSomething = assertions.ShouldSendSomething
-	ShouldSendN         = assertions.ShouldSendN
+	ShouldSend           = assertions.ShouldSend
+	ShouldSendTo         = assertions.ShouldSendTo
+	ShouldSendSomething  = assertions.ShouldSendSomething
+	ShouldSendN          = assertions.ShouldSendN
+	ShouldSendAll        = assertions.ShouldSendAll
+	ShouldSendInAnyOrder = assertions.ShouldSendInAnyOrder
+	ShouldEventuallySend = assertions.ShouldEventuallySend
 
-	ShouldNotSendOrReceive = assertions.ShouldNotSendOrReceive
+	ShouldNotReceive             = assertions.ShouldNotReceive
+	ShouldNotReceiveFrom         = assertions.ShouldNotReceiveFrom
+	ShouldNotSend                = assertions.ShouldNotSend
+	ShouldNotSendTo              = assertions.ShouldNotSendTo
+	ShouldNotSendOrReceive       = assertions.ShouldNotSendOrReceive
+	ShouldConsistentlyNotReceive = assertions.ShouldConsistentlyNotReceive
 
 	ShouldStart              = assertions.ShouldStart
 	ShouldStop               = assertions.ShouldStop
+	ShouldNotStop            = assertions.ShouldNotStop
 	ShouldBeRestarting       = assertions.ShouldBeRestarting
 	ShouldObserveTermination = assertions.ShouldObserveTermination
+	ShouldBeStopping         = assertions.ShouldBeStopping
+	ShouldReceiveRestart     = assertions.ShouldReceiveRestart
+	ShouldReceiveTimeout     = assertions.ShouldReceiveTimeout
+	ShouldBePoisoned         = assertions.ShouldBePoisoned
+	ShouldBeWatched          = assertions.ShouldBeWatched
+	ShouldBeUnwatched        = assertions.ShouldBeUnwatched
+	ShouldObserveFailure     = assertions.ShouldObserveFailure
+	ShouldReceiveSystem      = assertions.ShouldReceiveSystem
+
+	ShouldStopActor = assertions.ShouldStopActor
+	ShouldWatch     = assertions.ShouldWatch
+	ShouldUnwatch   = assertions.ShouldUnwatch
+	ShouldPoison    = assertions.ShouldPoison
+
+	ShouldFail     = assertions.ShouldFail
+	ShouldFailWith = assertions.ShouldFailWith
+	ShouldNotFail  = assertions.ShouldNotFail
 
-	ShouldSpawn = assertions.ShouldSpawn
+	ShouldResumeChild  = assertions.ShouldResumeChild
+	ShouldRestartChild = assertions.ShouldRestartChild
+	ShouldStopChild    = assertions.ShouldStopChild
+	ShouldEscalate     = assertions.ShouldEscalate
+
+	ShouldSpawn    = assertions.ShouldSpawn
+	ShouldNotSpawn = assertions.ShouldNotSpawn
+
+	ShouldMatchConversationSnapshot = assertions.ShouldMatchConversationSnapshot
 )
+
+// KeepSkipped can be passed to eventual assertions to keep
+// skipped messages for the following assertions:
+//
+//	So(myActor, ShouldEventuallySend, "pong", KeepSkipped)
+const KeepSkipped = gopactor.KeepSkipped
@@ -1,7 +1,9 @@
 package gopactor
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/AsynkronIT/protoactor-go/actor"
 	"github.com/meamidos/gopactor/options"
@@ -473,6 +475,7 @@ func TestShouldSpawn(t *testing.T) {
 	// Wrong params
 	a.Contains(ShouldSpawn(nil), "not an actor PID")
 	a.Contains(ShouldSpawn(parent, 123), "should be a string")
+	a.Contains(ShouldSpawn(parent, "child", "other"), "At most one parameter")
 
 	// Failure: Timeout
 	a.Contains(ShouldSpawn(parent), "Timeout")
@@ -492,3 +495,999 @@ func TestShouldSpawn(t *testing.T) {
 	// Cleanup
 	PactReset()
 }
+
+func TestJournaling(t *testing.T) {
+	a := assert.New(t)
+
+	receiver, _ := SpawnFromFunc(func(ctx actor.Context) {}, OptNoInterception.WithPrefix("rcv"))
+	sender, _ := SpawnFromFunc(func(ctx actor.Context) {
+		switch m := ctx.Message().(type) {
+		case string:
+			if m == "tell" {
+				ctx.Tell(receiver, "tell from sender")
+			}
+		}
+	}, OptDefault.WithJournaling().WithPrefix("snd"))
+
+	// Success: nothing is recorded
+	a.Empty(ShouldNotSendOrReceive(sender))
+
+	// The sender is not blocked by interception, so both messages
+	// are processed before anything is asserted.
+	sender.Tell("tell")
+	sender.Tell("tell")
+	time.Sleep(10 * time.Millisecond)
+
+	// Success: everything is recorded in order
+	a.Empty(ShouldReceive(sender, "tell"))
+	a.Empty(ShouldSend(sender, "tell from sender"))
+	a.Empty(ShouldReceive(sender, "tell"))
+	a.Empty(ShouldSendTo(sender, receiver, "tell from sender"))
+
+	// Failure: the journal is empty
+	a.Contains(ShouldReceiveSomething(sender), "Timeout")
+
+	// Failure: a recorded message
+	sender.Tell("foobar")
+	a.Contains(ShouldNotSendOrReceive(sender), "Got inbound message")
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldReceive_Matchers(t *testing.T) {
+	a := assert.New(t)
+
+	type Created struct {
+		ID   int
+		Name string
+	}
+
+	receiver, _ := SpawnFromFunc(func(ctx actor.Context) {}, OptDefault.WithPrefix("rcv"))
+
+	// Failure: Message mismatch
+	receiver.Tell(&Created{ID: 1, Name: "foo"})
+	a.Contains(ShouldReceive(receiver, MatchLike(&Created{Name: "bar"})), "a message like")
+
+	// Success: Type match
+	receiver.Tell(&Created{ID: 1, Name: "foo"})
+	a.Empty(ShouldReceive(receiver, MatchType(&Created{})))
+
+	// Success: Partial match
+	receiver.Tell(&Created{ID: 2, Name: "foo"})
+	a.Empty(ShouldReceive(receiver, MatchLike(&Created{Name: "foo"})))
+
+	// Success: Fields match
+	receiver.Tell(&Created{ID: 3, Name: "foo"})
+	a.Empty(ShouldReceive(receiver, MatchFields(map[string]interface{}{"Name": "foo"})))
+
+	// Success: Predicate match
+	receiver.Tell(&Created{ID: 4, Name: "foo"})
+	a.Empty(ShouldReceive(receiver, MatchFunc(func(msg interface{}) bool {
+		created, ok := msg.(*Created)
+		return ok && created.ID > 3
+	})))
+
+	// Success: Regexp match
+	receiver.Tell("user-42")
+	a.Empty(ShouldReceive(receiver, MatchRegexp(`^user-\d+$`)))
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldNotReceive(t *testing.T) {
+	a := assert.New(t)
+
+	receiver, _ := SpawnFromFunc(func(ctx actor.Context) {}, OptDefault.WithPrefix("rcv"))
+
+	// Wrong params
+	a.Contains(ShouldNotReceive(nil), "not an actor PID")
+	a.Contains(ShouldNotReceive(receiver, "a", "b"), "At most one parameter")
+
+	// Success: nothing received
+	a.Empty(ShouldNotReceive(receiver))
+	a.Empty(ShouldNotReceive(receiver, "forbidden"))
+
+	// Success: unrelated messages are allowed
+	receiver.Tell("allowed")
+	receiver.Tell(42)
+	a.Empty(ShouldNotReceive(receiver, "forbidden"))
+
+	// Failure: anything received
+	receiver.Tell("allowed")
+	a.Contains(ShouldNotReceive(receiver), "Received a forbidden message")
+
+	// Failure: the forbidden message follows an unrelated one
+	receiver.Tell("allowed")
+	receiver.Tell("forbidden")
+	a.Contains(ShouldNotReceive(receiver, "forbidden"), "Received a forbidden message")
+
+	// Failure: matcher
+	receiver.Tell(42)
+	a.Contains(ShouldNotReceive(receiver, MatchType(0)), "Received a forbidden message")
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldNotReceiveFrom(t *testing.T) {
+	a := assert.New(t)
+
+	receiver, _ := SpawnFromFunc(func(ctx actor.Context) {}, OptDefault.WithPrefix("rcv"))
+	requestor1, _ := SpawnNullActor(OptNoInterception.WithPrefix("req"))
+	requestor2, _ := SpawnNullActor(OptNoInterception.WithPrefix("req"))
+
+	// Wrong params
+	a.Contains(ShouldNotReceiveFrom(nil), "not an actor PID")
+	a.Contains(ShouldNotReceiveFrom(receiver), "A sender and an optional message are required")
+	a.Contains(ShouldNotReceiveFrom(receiver, nil), "Sender should be an actor PID")
+
+	// Success: messages from other senders are allowed
+	receiver.Request("ping", requestor2)
+	a.Empty(ShouldNotReceiveFrom(receiver, requestor1))
+
+	// Success: other messages from the sender are allowed
+	receiver.Request("ping", requestor1)
+	a.Empty(ShouldNotReceiveFrom(receiver, requestor1, "forbidden"))
+
+	// Failure: anything from the sender
+	receiver.Request("ping", requestor1)
+	a.Contains(ShouldNotReceiveFrom(receiver, requestor1), "Received a forbidden message")
+
+	// Failure: the forbidden message from the sender
+	receiver.Request("forbidden", requestor2)
+	receiver.Request("forbidden", requestor1)
+	a.Contains(ShouldNotReceiveFrom(receiver, requestor1, "forbidden"), "Received a forbidden message")
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldNotSend(t *testing.T) {
+	a := assert.New(t)
+
+	receiver, _ := actor.SpawnPrefix(actor.FromFunc(func(ctx actor.Context) {}), "rcv")
+	sender, _ := SpawnFromFunc(func(ctx actor.Context) {
+		if m, ok := ctx.Message().(string); ok {
+			ctx.Tell(receiver, m)
+		}
+	}, options.OptOutboundInterceptionOnly.WithPrefix("snd"))
+
+	// Wrong params
+	a.Contains(ShouldNotSend(nil), "not an actor PID")
+	a.Contains(ShouldNotSend(sender, "a", "b"), "At most one parameter")
+
+	// Success: nothing sent
+	a.Empty(ShouldNotSend(sender))
+
+	// Success: unrelated messages are allowed
+	sender.Tell("allowed")
+	a.Empty(ShouldNotSend(sender, "forbidden"))
+
+	// Failure: anything sent
+	sender.Tell("allowed")
+	a.Contains(ShouldNotSend(sender), "Sent a forbidden message")
+
+	// Failure: the forbidden message
+	sender.Tell("allowed")
+	sender.Tell("forbidden")
+	a.Contains(ShouldNotSend(sender, MatchRegexp("^forb")), "Sent a forbidden message")
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldNotSendTo(t *testing.T) {
+	a := assert.New(t)
+
+	receiver1, _ := actor.SpawnPrefix(actor.FromFunc(func(ctx actor.Context) {}), "rcv")
+	receiver2, _ := actor.SpawnPrefix(actor.FromFunc(func(ctx actor.Context) {}), "rcv")
+	sender, _ := SpawnFromFunc(func(ctx actor.Context) {
+		if m, ok := ctx.Message().(string); ok {
+			ctx.Tell(receiver1, m)
+			ctx.Tell(receiver2, m)
+		}
+	}, options.OptOutboundInterceptionOnly.WithPrefix("snd"))
+
+	// Wrong params
+	a.Contains(ShouldNotSendTo(nil), "not an actor PID")
+	a.Contains(ShouldNotSendTo(sender), "A receiver and an optional message are required")
+	a.Contains(ShouldNotSendTo(sender, nil), "Receiver should be an actor PID")
+
+	// Success: nothing sent
+	a.Empty(ShouldNotSendTo(sender, receiver1))
+
+	// Success: other messages to the receiver are allowed
+	sender.Tell("allowed")
+	a.Empty(ShouldNotSendTo(sender, receiver2, "forbidden"))
+
+	// Failure: anything sent to the receiver
+	sender.Tell("allowed")
+	a.Contains(ShouldNotSendTo(sender, receiver2), "Sent a forbidden message")
+
+	// Failure: the forbidden message sent to the receiver
+	sender.Tell("forbidden")
+	a.Contains(ShouldNotSendTo(sender, receiver2, "forbidden"), "Sent a forbidden message")
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldNotSpawn(t *testing.T) {
+	a := assert.New(t)
+
+	childProps := actor.FromFunc(func(ctx actor.Context) {})
+	parent, _ := SpawnFromFunc(func(ctx actor.Context) {
+		if m, ok := ctx.Message().(string); ok {
+			ctx.SpawnPrefix(childProps, m)
+		}
+	}, options.OptNoInterception.WithSpawnInterception().WithPrefix("parent"))
+
+	// Wrong params
+	a.Contains(ShouldNotSpawn(nil), "not an actor PID")
+	a.Contains(ShouldNotSpawn(parent, 123), "should be a string")
+	a.Contains(ShouldNotSpawn(parent, "child", "other"), "At most one parameter")
+
+	// Success: nothing spawned
+	a.Empty(ShouldNotSpawn(parent))
+
+	// Success: another child is allowed
+	parent.Tell("allowed-child")
+	a.Empty(ShouldNotSpawn(parent, "forbidden-child"))
+
+	// Failure: any child
+	parent.Tell("allowed-child")
+	a.Contains(ShouldNotSpawn(parent), "Spawned a forbidden child")
+
+	// Failure: the forbidden child
+	parent.Tell("forbidden-child")
+	a.Contains(ShouldNotSpawn(parent, "forbidden-child"), "Spawned a forbidden child")
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldNotStop(t *testing.T) {
+	a := assert.New(t)
+
+	receiver, _ := SpawnFromFunc(func(ctx actor.Context) {}, OptNoInterception.WithSystemInterception().WithPrefix("rcv"))
+
+	// Wrong params
+	a.Contains(ShouldNotStop(nil), "not an actor PID")
+
+	// Success: the Started message does not count
+	a.Empty(ShouldNotStop(receiver))
+
+	// Failure
+	receiver.Stop()
+	a.Contains(ShouldNotStop(receiver), "forbidden system message")
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldFail(t *testing.T) {
+	a := assert.New(t)
+
+	receiver, _ := SpawnFromFunc(func(ctx actor.Context) {
+		switch m := ctx.Message().(type) {
+		case string:
+			if m == "panic" {
+				panic("I am panicing!")
+			}
+		case error:
+			panic(m)
+		}
+	}, OptNoInterception.WithSystemInterception().WithPrefix("rcv"))
+
+	// Wrong params
+	a.Contains(ShouldFail(nil), "not an actor PID")
+	a.Contains(ShouldFailWith(nil), "not an actor PID")
+	a.Contains(ShouldFailWith(receiver), "reason is required")
+	a.Contains(ShouldFailWith(receiver, nil), "reason is required")
+	a.Contains(ShouldNotFail(nil), "not an actor PID")
+
+	// Failure: Timeout
+	a.Contains(ShouldFail(receiver), "Timeout")
+
+	// Success: no failure
+	receiver.Tell("ok")
+	a.Empty(ShouldNotFail(receiver))
+
+	// Success: any failure
+	receiver.Tell("panic")
+	a.Empty(ShouldFail(receiver))
+	a.Empty(ShouldBeRestarting(receiver))
+	a.Empty(ShouldStart(receiver))
+
+	// Success: exact reason
+	receiver.Tell("panic")
+	a.Empty(ShouldFailWith(receiver, "I am panicing!"))
+
+	// Success: reason matcher
+	receiver.Tell(fmt.Errorf("out of %s", "coffee"))
+	a.Empty(ShouldFailWith(receiver, MatchRegexp("coffee")))
+
+	// Failure: reason mismatch
+	receiver.Tell("panic")
+	a.Contains(ShouldFailWith(receiver, "I am fine"), "Timeout")
+
+	// Failure: the actor fails
+	receiver.Tell("panic")
+	a.Contains(ShouldNotFail(receiver), "forbidden system message")
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldDecideAboutChild(t *testing.T) {
+	a := assert.New(t)
+
+	decider := func(reason interface{}) actor.Directive {
+		switch reason {
+		case "resume":
+			return actor.ResumeDirective
+		case "stop":
+			return actor.StopDirective
+		case "escalate":
+			return actor.EscalateDirective
+		}
+		return actor.RestartDirective
+	}
+
+	childProps := actor.FromFunc(func(ctx actor.Context) {
+		if m, ok := ctx.Message().(string); ok {
+			panic(m)
+		}
+	})
+
+	getParentChildSet := func() (*actor.PID, *actor.PID) {
+		var child *actor.PID
+		wait := make(chan bool)
+		parent, _ := SpawnFromFunc(func(ctx actor.Context) {
+			switch ctx.Message().(type) {
+			case *actor.Started:
+				child = ctx.SpawnPrefix(childProps, "child")
+				wait <- true
+			}
+		}, OptNoInterception.
+			WithSupervisorStrategy(actor.NewOneForOneStrategy(10, time.Second, decider)).
+			WithSupervisionRecording().
+			WithPrefix("parent"))
+
+		<-wait
+
+		return parent, child
+	}
+
+	parent, child := getParentChildSet()
+	someActor, _ := SpawnNullActor()
+
+	// Wrong params
+	a.Contains(ShouldResumeChild(nil), "not an actor PID")
+	a.Contains(ShouldRestartChild(parent, "child"), "should be an actor PID")
+	a.Contains(ShouldStopChild(parent, someActor, someActor), "At most one parameter")
+	a.Contains(ShouldEscalate(someActor), "Timeout")
+
+	// Failure: Timeout
+	a.Contains(ShouldStopChild(parent), "Timeout")
+
+	// Failure: Directive mismatch
+	child.Tell("resume")
+	a.Contains(ShouldRestartChild(parent, child), "directive does not match")
+
+	// Failure: Child mismatch
+	child.Tell("resume")
+	a.Contains(ShouldResumeChild(parent, someActor), "child does not match")
+
+	// Success: Resume
+	child.Tell("resume")
+	a.Empty(ShouldResumeChild(parent, child))
+
+	// Success: Restart
+	child.Tell("restart")
+	a.Empty(ShouldRestartChild(parent, child))
+
+	// Success: Stop
+	child.Tell("stop")
+	a.Empty(ShouldStopChild(parent))
+
+	// Success: Escalate
+	parent, child = getParentChildSet()
+	child.Tell("escalate")
+	a.Empty(ShouldEscalate(parent, child))
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldSendAll(t *testing.T) {
+	a := assert.New(t)
+
+	workers := make([]*actor.PID, 3)
+	for i := range workers {
+		workers[i], _ = SpawnNullActor(OptNoInterception)
+	}
+
+	// Broadcasts to all workers in a random order
+	coordinator, _ := SpawnFromFunc(func(ctx actor.Context) {
+		if ctx.Message() == "go" {
+			for _, i := range []int{2, 0, 1} {
+				ctx.Tell(workers[i], fmt.Sprintf("job-%d", i))
+			}
+		}
+	}, OptOutboundInterceptionOnly.WithJournaling().WithTimeout(20*time.Millisecond))
+
+	// Wrong params
+	a.Contains(ShouldSendAll(nil), "not an actor PID")
+	a.Contains(ShouldSendAll(coordinator), "At least one expected message is required")
+	a.Contains(ShouldSendInAnyOrder(coordinator), "At least one expected message is required")
+
+	// Success: any order
+	coordinator.Tell("go")
+	a.Empty(ShouldSendAll(coordinator, "job-0", "job-1", "job-2"))
+
+	// Success: with receivers and matchers, other messages are ignored
+	coordinator.Tell("go")
+	a.Empty(ShouldSendAll(coordinator, MsgTo(workers[0], "job-0"), MsgTo(workers[1], MatchType(""))))
+
+	// Ignored messages are consumed as well
+	a.Empty(ShouldNotSend(coordinator))
+
+	// Overlapping expectations do not depend on the order of arrival
+	coordinator.Tell("go")
+	a.Empty(ShouldSendAll(coordinator, MatchType(""), "job-2", MatchRegexp("job-[01]")))
+
+	// Failure: the report tells what is met, what is missing and what is unexpected
+	coordinator.Tell("go")
+	res := ShouldSendAll(coordinator, "job-0", MsgTo(workers[0], "job-1"))
+	a.Contains(res, "Timeout")
+	a.Contains(res, `Met: "job-0"`)
+	a.Contains(res, `Missing: "job-1" to `+workers[0].String())
+	a.Contains(res, `Unexpected: "job-2"`)
+
+	// Strict: exactly these messages
+	coordinator.Tell("go")
+	a.Empty(ShouldSendInAnyOrder(coordinator, "job-1", "job-2", "job-0"))
+
+	coordinator.Tell("go")
+	res = ShouldSendInAnyOrder(coordinator, "job-0", "job-1")
+	a.Contains(res, "Got an unexpected message")
+	a.Contains(res, `Unexpected: "job-2"`)
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldReceiveAll(t *testing.T) {
+	a := assert.New(t)
+
+	sender1, _ := SpawnNullActor(OptNoInterception)
+	sender2, _ := SpawnNullActor(OptNoInterception)
+	receiver, _ := SpawnNullActor(OptInboundInterceptionOnly.WithJournaling())
+
+	// Wrong params
+	a.Contains(ShouldReceiveAll(nil), "not an actor PID")
+	a.Contains(ShouldReceiveAll(receiver), "At least one expected message is required")
+
+	// Success
+	receiver.Request("done", sender2)
+	receiver.Tell("noise")
+	receiver.Request("done", sender1)
+	a.Empty(ShouldReceiveAll(receiver, MsgFrom(sender1, "done"), MsgFrom(sender2, "done")))
+
+	// Failure
+	receiver.Request("done", sender1)
+	res := ShouldReceiveAll(receiver, MsgFrom(sender2, "done"))
+	a.Contains(res, "Missing")
+	a.Contains(res, `Unexpected: "done"`)
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldEventuallyReceive(t *testing.T) {
+	a := assert.New(t)
+
+	receiver, _ := SpawnNullActor(OptInboundInterceptionOnly.WithJournaling().WithTimeout(20 * time.Millisecond))
+
+	// Wrong params
+	a.Contains(ShouldEventuallyReceive(nil), "not an actor PID")
+	a.Contains(ShouldEventuallyReceive(receiver), "One parameter with a message is required")
+	a.Contains(ShouldEventuallyReceive(receiver, "ready", "what"), "Unknown parameter")
+
+	// Success: heartbeats are skipped
+	receiver.Tell("heartbeat")
+	receiver.Tell("heartbeat")
+	receiver.Tell("ready")
+	a.Empty(ShouldEventuallyReceive(receiver, "ready"))
+	a.Empty(ShouldNotReceive(receiver))
+
+	// Success: skipped messages are kept for the following assertions
+	receiver.Tell("heartbeat")
+	receiver.Tell("ready")
+	a.Empty(ShouldEventuallyReceive(receiver, "ready", KeepSkipped))
+	a.Empty(ShouldReceive(receiver, "heartbeat"))
+
+	// Failure: the report tells what was skipped
+	receiver.Tell("heartbeat")
+	res := ShouldEventuallyReceive(receiver, "ready", 10*time.Millisecond)
+	a.Contains(res, "Timeout")
+	a.Contains(res, `Missing: "ready"`)
+	a.Contains(res, `Unexpected: "heartbeat"`)
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldEventuallySend(t *testing.T) {
+	a := assert.New(t)
+
+	receiver, _ := SpawnNullActor(OptNoInterception)
+	sender, _ := SpawnFromFunc(func(ctx actor.Context) {
+		if ctx.Message() == "go" {
+			ctx.Tell(receiver, "heartbeat")
+			ctx.Tell(receiver, "done")
+		}
+	}, OptOutboundInterceptionOnly.WithJournaling().WithTimeout(20*time.Millisecond))
+
+	// Wrong params
+	a.Contains(ShouldEventuallySend(nil), "not an actor PID")
+	a.Contains(ShouldEventuallySend(sender), "One parameter with a message is required")
+
+	// Success
+	sender.Tell("go")
+	a.Empty(ShouldEventuallySend(sender, "done"))
+
+	// Success: with a receiver, keeping the skipped messages
+	sender.Tell("go")
+	a.Empty(ShouldEventuallySend(sender, MsgTo(receiver, "done"), KeepSkipped))
+	a.Empty(ShouldSend(sender, "heartbeat"))
+
+	// Failure
+	sender.Tell("go")
+	a.Contains(ShouldEventuallySend(sender, "ready"), "Timeout")
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldConsistentlyNotReceive(t *testing.T) {
+	a := assert.New(t)
+
+	receiver, _ := SpawnNullActor(OptInboundInterceptionOnly.WithJournaling())
+
+	// Wrong params
+	a.Contains(ShouldConsistentlyNotReceive(nil), "not an actor PID")
+	a.Contains(ShouldConsistentlyNotReceive(receiver, "stop", "now"), "Only a message and a window")
+
+	// Success: other messages are fine
+	receiver.Tell("heartbeat")
+	a.Empty(ShouldConsistentlyNotReceive(receiver, "stop", 20*time.Millisecond))
+
+	// Failure: the forbidden message arrives late in the window
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		receiver.Tell("stop")
+	}()
+	a.NotEmpty(ShouldConsistentlyNotReceive(receiver, "stop", 50*time.Millisecond))
+
+	// Failure: nothing at all is allowed
+	receiver.Tell("heartbeat")
+	a.NotEmpty(ShouldConsistentlyNotReceive(receiver))
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldWatch(t *testing.T) {
+	a := assert.New(t)
+
+	other, _ := SpawnNullActor(OptNoInterception)
+	watcher, _ := SpawnFromFunc(func(ctx actor.Context) {
+		switch ctx.Message() {
+		case "watch":
+			ctx.Watch(other)
+		case "unwatch":
+			ctx.Unwatch(other)
+		}
+	}, OptNoInterception.WithOutboundSystemInterception())
+
+	// Wrong params
+	a.Contains(ShouldWatch(nil), "not an actor PID")
+	a.Contains(ShouldWatch(watcher, "other"), "should be an actor PID")
+	a.Contains(ShouldWatch(watcher, other, other), "Only one parameter")
+
+	// Failure: Timeout
+	a.Contains(ShouldWatch(watcher, other), "Timeout")
+
+	// Success
+	watcher.Tell("watch")
+	a.Empty(ShouldWatch(watcher, other))
+
+	watcher.Tell("unwatch")
+	a.Empty(ShouldUnwatch(watcher))
+
+	// Failure: Target mismatch
+	watcher.Tell("watch")
+	a.Contains(ShouldWatch(watcher, watcher), "Timeout")
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldPoison(t *testing.T) {
+	a := assert.New(t)
+
+	victim, _ := SpawnNullActor(OptNoInterception)
+	killer, _ := SpawnFromFunc(func(ctx actor.Context) {
+		if ctx.Message() == "kill" {
+			ctx.Tell(victim, &actor.PoisonPill{})
+		}
+	}, OptDefault.WithOutboundSystemInterception())
+
+	// Success: the poison pill is not a user message
+	killer.Tell("kill")
+	a.Empty(ShouldReceive(killer, "kill"))
+	a.Empty(ShouldPoison(killer, victim))
+	a.Empty(ShouldNotSend(killer))
+
+	// Failure: Timeout
+	a.Contains(ShouldPoison(killer), "Timeout")
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldStopActor(t *testing.T) {
+	a := assert.New(t)
+
+	childProps := actor.FromFunc(func(ctx actor.Context) {
+		if m, ok := ctx.Message().(string); ok {
+			panic(m)
+		}
+	})
+
+	var child *actor.PID
+	wait := make(chan bool)
+	parent, _ := SpawnFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*actor.Started); ok {
+			child = ctx.SpawnPrefix(childProps, "child")
+			wait <- true
+		}
+	}, OptNoInterception.
+		WithSupervisorStrategy(actor.NewOneForOneStrategy(10, time.Second, func(interface{}) actor.Directive {
+			return actor.StopDirective
+		})).
+		WithSupervisionRecording().
+		WithOutboundSystemInterception())
+
+	<-wait
+
+	// Wrong params
+	a.Contains(ShouldStopActor(nil), "not an actor PID")
+
+	// Success: the supervisor stops the failed child
+	child.Tell("boom")
+	a.Empty(ShouldStopActor(parent, child))
+	a.Empty(ShouldStopChild(parent, child))
+
+	// Failure: Timeout
+	a.Contains(ShouldStopActor(parent), "Timeout")
+
+	// Cleanup
+	PactReset()
+}
+
+// Stopping other actors through the context, as in later versions of Protoactor
+func TestShouldStopActor_Pid(t *testing.T) {
+	a := assert.New(t)
+
+	poisoned, _ := SpawnNullActor(OptNoInterception)
+	stopped, _ := SpawnNullActor(OptNoInterception.WithSystemInterception())
+	a.Empty(ShouldStart(stopped))
+
+	killer, _ := SpawnFromFunc(func(ctx actor.Context) {
+		switch ctx.Message() {
+		case "poison":
+			ctx.Tell(poisoned, &actor.PoisonPill{})
+		case "stop":
+			stopped.Stop()
+		}
+	}, OptNoInterception.WithOutboundSystemInterception())
+
+	// A PoisonPill goes through the context
+	killer.Tell("poison")
+	a.Empty(ShouldPoison(killer, poisoned))
+
+	// pid.Stop() does not, but the stopped actor can be asserted on
+	killer.Tell("stop")
+	a.Empty(ShouldStop(stopped))
+	a.Contains(ShouldStopActor(killer, stopped), "Timeout")
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldStopActor_FollowedChild(t *testing.T) {
+	a := assert.New(t)
+
+	childProps := actor.FromFunc(func(ctx actor.Context) {
+		if m, ok := ctx.Message().(string); ok {
+			panic(m)
+		}
+	})
+
+	var child *actor.PID
+	wait := make(chan bool)
+	parent, _ := SpawnFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*actor.Started); ok {
+			child = ctx.SpawnPrefix(childProps, "child")
+			wait <- true
+		}
+	}, OptNoInterception.
+		WithSupervisorStrategy(actor.NewOneForOneStrategy(10, time.Second, func(interface{}) actor.Directive {
+			return actor.StopDirective
+		})).
+		WithSystemInterception().
+		WithOutboundSystemInterception().
+		WithRecursiveInterception(1).
+		WithTimeout(time.Second))
+
+	<-wait
+
+	// The strategy of the parent is not replaced, the child reports itself
+	child.Tell("boom")
+	a.Empty(ShouldObserveFailure(parent, child))
+	a.Empty(ShouldStopActor(parent, child))
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldStopActor_ResumedChild(t *testing.T) {
+	a := assert.New(t)
+
+	childProps := actor.FromFunc(func(ctx actor.Context) {
+		if m, ok := ctx.Message().(string); ok {
+			panic(m)
+		}
+	})
+
+	var child *actor.PID
+	wait := make(chan bool)
+	decided := make(chan bool, 1)
+	parent, _ := SpawnFromFunc(func(ctx actor.Context) {
+		switch ctx.Message().(type) {
+		case *actor.Started:
+			child = ctx.SpawnPrefix(childProps, "child")
+			wait <- true
+		case string:
+			ctx.Respond("synced")
+		}
+	}, OptNoInterception.
+		WithSupervisorStrategy(actor.NewOneForOneStrategy(10, time.Second, func(interface{}) actor.Directive {
+			decided <- true
+			return actor.ResumeDirective
+		})).
+		WithSystemInterception().
+		WithOutboundSystemInterception().
+		WithRecursiveInterception(1).
+		WithTimeout(100 * time.Millisecond))
+
+	<-wait
+
+	child.Tell("boom")
+	a.Empty(ShouldObserveFailure(parent, child))
+
+	// The parent handles the request after it has resumed the child
+	<-decided
+	_, err := parent.RequestFuture("sync", time.Second).Result()
+	a.Nil(err)
+
+	// A resumed child stopped from outside is not stopped by the supervisor
+	child.Stop()
+	a.Empty(ShouldStop(child))
+	a.Contains(ShouldStopActor(parent, child), "Timeout")
+
+	// Cleanup
+	PactReset()
+}
+
+func TestSystemInterception_KeepsSupervisor(t *testing.T) {
+	a := assert.New(t)
+
+	childProps := actor.FromFunc(func(ctx actor.Context) {
+		if m, ok := ctx.Message().(string); ok {
+			panic(m)
+		}
+	})
+
+	parent, _ := SpawnFromFunc(func(ctx actor.Context) {
+		switch ctx.Message() {
+		case "fail":
+			child := ctx.Spawn(childProps)
+			for i := 0; i < 20; i++ {
+				child.Tell("boom")
+			}
+		case "ping":
+			ctx.Respond("pong")
+		}
+	}, OptNoInterception.WithSystemInterception().WithRealSpawning())
+
+	// Unread failures of children do not block the parent
+	parent.Tell("fail")
+	time.Sleep(20 * time.Millisecond)
+	result, err := parent.RequestFuture("ping", time.Second).Result()
+	a.Nil(err)
+	a.Equal("pong", result)
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldBeStopping(t *testing.T) {
+	a := assert.New(t)
+
+	object, _ := SpawnNullActor(OptNoInterception.WithSystemInterception())
+
+	// Wrong params
+	a.Contains(ShouldBeStopping(nil), "not an actor PID")
+
+	// Failure: Timeout
+	a.Contains(ShouldBeStopping(object), "Timeout")
+
+	// Success
+	object.Stop()
+	a.Empty(ShouldBeStopping(object))
+	a.Empty(ShouldStop(object))
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldReceiveTimeout(t *testing.T) {
+	a := assert.New(t)
+
+	object, _ := SpawnFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*actor.Started); ok {
+			ctx.SetReceiveTimeout(5 * time.Millisecond)
+		}
+	}, OptDefault.WithSystemInterception().WithTimeout(50*time.Millisecond))
+
+	// Wrong params
+	a.Contains(ShouldReceiveTimeout(nil), "not an actor PID")
+
+	// Success: the timeout is not a user message
+	a.Empty(ShouldReceiveTimeout(object))
+	a.Empty(ShouldNotReceive(object))
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldBePoisoned(t *testing.T) {
+	a := assert.New(t)
+
+	object, _ := SpawnNullActor(OptNoInterception.WithSystemInterception())
+
+	// Wrong params
+	a.Contains(ShouldBePoisoned(nil), "not an actor PID")
+
+	// Failure: Timeout
+	a.Contains(ShouldBePoisoned(object), "Timeout")
+
+	// Success
+	object.Tell(&actor.PoisonPill{})
+	a.Empty(ShouldBePoisoned(object))
+	a.Empty(ShouldStop(object))
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldBeWatched(t *testing.T) {
+	a := assert.New(t)
+
+	object, _ := SpawnNullActor(OptNoInterception.WithSystemInterception())
+	watcher, _ := SpawnFromFunc(func(ctx actor.Context) {
+		switch ctx.Message() {
+		case "watch":
+			ctx.Watch(object)
+		case "unwatch":
+			ctx.Unwatch(object)
+		}
+	}, OptNoInterception)
+	someActor, _ := SpawnNullActor(OptNoInterception)
+
+	// Wrong params
+	a.Contains(ShouldBeWatched(nil), "not an actor PID")
+	a.Contains(ShouldBeUnwatched(object, "watcher"), "should be an actor PID")
+	a.Contains(ShouldBeWatched(object, object, object), "At most one parameter")
+
+	// Failure: Timeout
+	a.Contains(ShouldBeWatched(object), "Timeout")
+
+	// Success
+	watcher.Tell("watch")
+	a.Empty(ShouldBeWatched(object, watcher))
+
+	watcher.Tell("unwatch")
+	a.Empty(ShouldBeUnwatched(object))
+
+	// Failure: Watcher mismatch
+	watcher.Tell("watch")
+	a.Contains(ShouldBeWatched(object, someActor), "Timeout")
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldObserveFailure(t *testing.T) {
+	a := assert.New(t)
+
+	childProps := actor.FromFunc(func(ctx actor.Context) {
+		if m, ok := ctx.Message().(string); ok {
+			panic(m)
+		}
+	})
+
+	var child *actor.PID
+	wait := make(chan bool)
+	parent, _ := SpawnFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*actor.Started); ok {
+			child = ctx.SpawnPrefix(childProps, "child")
+			wait <- true
+		}
+	}, OptNoInterception.WithSystemInterception().WithRecursiveInterception(1))
+
+	<-wait
+
+	// Wrong params
+	a.Contains(ShouldObserveFailure(nil), "not an actor PID")
+	a.Contains(ShouldObserveFailure(parent, "child"), "should be an actor PID")
+
+	// Failure: Timeout
+	a.Contains(ShouldObserveFailure(parent), "Timeout")
+
+	// Success: the parent observes the failure, the child is restarted
+	child.Tell("boom")
+	a.Empty(ShouldFail(child))
+	a.Empty(ShouldObserveFailure(parent, child))
+	a.Empty(ShouldReceiveRestart(child))
+	a.Empty(ShouldBeRestarting(child))
+
+	// Success: any child
+	child.Tell("boom")
+	a.Empty(ShouldObserveFailure(parent))
+
+	// Wrong params
+	a.Contains(ShouldReceiveRestart(nil), "not an actor PID")
+
+	// Cleanup
+	PactReset()
+}
+
+func TestShouldReceiveSystem(t *testing.T) {
+	a := assert.New(t)
+
+	object, _ := SpawnNullActor(OptNoInterception.WithSystemInterception())
+
+	// Wrong params
+	a.Contains(ShouldReceiveSystem(nil), "not an actor PID")
+	a.Contains(ShouldReceiveSystem(object), "One parameter with a system message or a matcher is required")
+
+	// Success: with a matcher
+	a.Empty(ShouldReceiveSystem(object, MatchType(&actor.Started{})))
+
+	// Success: with a message
+	object.Stop()
+	a.Empty(ShouldReceiveSystem(object, &actor.Stopped{}))
+
+	// Failure: Timeout
+	a.Contains(ShouldReceiveSystem(object, &actor.Restarting{}), "Timeout")
+
+	// Cleanup
+	PactReset()
+}
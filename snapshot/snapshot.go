@@ -0,0 +1,281 @@
+// Package snapshot locks down conversations among actors with golden files.
+// A conversation is serialized into a stable text format: PIDs are replaced
+// with the prefixes of the actors, and volatile fields of messages
+// can be redacted. The text is compared with a golden file under testdata/.
+// Run the tests with GOPACTOR_UPDATE=1 to regenerate the golden files.
+// If the tests define an -update flag, it works as well.
+//
+// Example:
+//
+//   So(server, ShouldMatchConversationSnapshot, "login")
+//   So([]*actor.PID{client, server}, ShouldMatchConversationSnapshot, "login", "SessionID")
+//
+//   // GOPACTOR_UPDATE=1 go test ./...
+package snapshot
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/catcher"
+	"github.com/meamidos/gopactor/trace"
+)
+
+// GOLDEN_DIR is the directory of golden files, relative to the package under test.
+var GOLDEN_DIR = "testdata"
+
+// REDACTED replaces the values of redacted fields.
+const REDACTED = "<redacted>"
+
+// MAX_DEPTH limits the nesting of formatted values to stop on cyclic references.
+const MAX_DEPTH = 10
+
+// UPDATE_ENV is the environment variable which makes Match regenerate golden files.
+const UPDATE_ENV = "GOPACTOR_UPDATE"
+
+// updating tells whether golden files should be regenerated: either by the environment
+// variable, or by an -update flag defined by the tests. The package does not define
+// the flag itself, so that it does not clash with the flag of the tests.
+func updating() bool {
+	if update, err := strconv.ParseBool(os.Getenv(UPDATE_ENV)); err == nil && update {
+		return true
+	}
+
+	f := flag.Lookup("update")
+	if f == nil {
+		return false
+	}
+
+	getter, ok := f.Value.(flag.Getter)
+	if !ok {
+		return false
+	}
+
+	update, ok := getter.Get().(bool)
+	return ok && update
+}
+
+// Render serializes a conversation into a stable text format,
+// one line per event. Fields with the given names are redacted
+// in all messages, at any depth.
+func Render(t *trace.Trace, redacted ...string) string {
+	f := &formatter{
+		names:    newNamer(),
+		redacted: make(map[string]bool, len(redacted)),
+	}
+	for _, field := range redacted {
+		f.redacted[field] = true
+	}
+
+	var b bytes.Buffer
+	for _, event := range t.Events {
+		from := f.names.name(event.From)
+		if event.IsNote() {
+			note := event.Note
+			if event.About != nil {
+				note = strings.Replace(note, event.About.String(), f.names.name(event.About), -1)
+			}
+			fmt.Fprintf(&b, "note over %s: %s\n", from, note)
+		} else {
+			fmt.Fprintf(&b, "%s -> %s: %s\n", from, f.names.name(event.To), f.format(reflect.ValueOf(event.Message), 0))
+		}
+	}
+
+	return b.String()
+}
+
+// Match compares a rendered conversation with the golden file of a given name.
+// With the -update flag, the golden file is written instead.
+func Match(name string, actual string) error {
+	path := filepath.Join(GOLDEN_DIR, name+".golden")
+
+	if updating() {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return &catcher.Failure{Reason: fmt.Sprintf("Could not create %s: %s", filepath.Dir(path), err)}
+		}
+		if err := ioutil.WriteFile(path, []byte(actual), 0644); err != nil {
+			return &catcher.Failure{Reason: fmt.Sprintf("Could not update %s: %s", path, err)}
+		}
+		return nil
+	}
+
+	expected, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &catcher.Failure{Reason: fmt.Sprintf("Snapshot %s does not exist, run the tests with -update to create it", path)}
+	} else if err != nil {
+		return &catcher.Failure{Reason: fmt.Sprintf("Could not read %s: %s", path, err)}
+	}
+
+	if string(expected) != actual {
+		return &catcher.Failure{
+			Reason:   fmt.Sprintf("Conversation does not match snapshot %s (line %d)", path, firstDifference(string(expected), actual)),
+			Expected: "\n" + string(expected),
+			Actual:   "\n" + actual,
+		}
+	}
+
+	return nil
+}
+
+func firstDifference(expected, actual string) int {
+	expectedLines := strings.Split(expected, "\n")
+	actualLines := strings.Split(actual, "\n")
+
+	for i := range expectedLines {
+		if i >= len(actualLines) || expectedLines[i] != actualLines[i] {
+			return i + 1
+		}
+	}
+
+	return len(expectedLines) + 1
+}
+
+// namer gives actors stable names: the ID without the address
+// and without the generated counters, e.g. "server/worker".
+// Different actors with the same name are numbered in order of appearance.
+type namer struct {
+	byPID  map[string]string
+	counts map[string]int
+}
+
+var generatedID = regexp.MustCompile(`\$\d+`)
+
+func newNamer() *namer {
+	return &namer{
+		byPID:  map[string]string{},
+		counts: map[string]int{},
+	}
+}
+
+func (n *namer) name(pid *actor.PID) string {
+	if pid == nil {
+		return trace.UNKNOWN_SENDER
+	}
+
+	if name, ok := n.byPID[pid.String()]; ok {
+		return name
+	}
+
+	segments := strings.Split(pid.Id, "/")
+	for i, segment := range segments {
+		segments[i] = generatedID.ReplaceAllString(segment, "")
+		if segments[i] == "" {
+			segments[i] = "actor"
+		}
+	}
+
+	base := strings.Join(segments, "/")
+	n.counts[base]++
+
+	name := base
+	if n.counts[base] > 1 {
+		name = fmt.Sprintf("%s#%d", base, n.counts[base])
+	}
+
+	n.byPID[pid.String()] = name
+	return name
+}
+
+// formatter prints values like %+v does, but without pointer addresses,
+// with PIDs replaced by names, with redacted fields and with sorted maps.
+type formatter struct {
+	names    *namer
+	redacted map[string]bool
+}
+
+var pidType = reflect.TypeOf(&actor.PID{})
+
+func (f *formatter) format(value reflect.Value, depth int) string {
+	if !value.IsValid() {
+		return "nil"
+	}
+
+	if depth > MAX_DEPTH {
+		return "..."
+	}
+
+	if value.Type() == pidType {
+		if value.IsNil() {
+			return "nil"
+		}
+		return f.names.name(value.Interface().(*actor.PID))
+	}
+
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return "nil"
+		}
+		return "&" + f.format(value.Elem(), depth+1)
+
+	case reflect.Interface:
+		if value.IsNil() {
+			return "nil"
+		}
+		return f.format(value.Elem(), depth+1)
+
+	case reflect.Struct:
+		fields := make([]string, 0, value.NumField())
+		for i := 0; i < value.NumField(); i++ {
+			name := value.Type().Field(i).Name
+			if f.redacted[name] {
+				fields = append(fields, name+":"+REDACTED)
+			} else {
+				fields = append(fields, name+":"+f.format(value.Field(i), depth+1))
+			}
+		}
+		return fmt.Sprintf("%v{%s}", value.Type(), strings.Join(fields, " "))
+
+	case reflect.Map:
+		if value.IsNil() {
+			return "nil"
+		}
+		entries := make([]string, 0, value.Len())
+		for _, key := range value.MapKeys() {
+			entries = append(entries, f.format(key, depth+1)+":"+f.format(value.MapIndex(key), depth+1))
+		}
+		sort.Strings(entries)
+		return fmt.Sprintf("map[%s]", strings.Join(entries, " "))
+
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return "nil"
+		}
+		items := make([]string, value.Len())
+		for i := range items {
+			items[i] = f.format(value.Index(i), depth+1)
+		}
+		return fmt.Sprintf("[%s]", strings.Join(items, " "))
+
+	case reflect.String:
+		return strconv.Quote(value.String())
+
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool())
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(value.Uint(), 10)
+
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 64)
+
+	case reflect.Complex64, reflect.Complex128:
+		return fmt.Sprint(value.Complex())
+	}
+
+	// Channels, functions and unsafe pointers have no stable representation
+	return value.Type().String()
+}
@@ -0,0 +1,95 @@
+package snapshot_test
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/catcher"
+	"github.com/meamidos/gopactor/snapshot"
+	"github.com/meamidos/gopactor/trace"
+	"github.com/stretchr/testify/assert"
+)
+
+// The usual flag of golden file tests does not clash with the package
+var update = flag.Bool("update", false, "update golden files")
+
+type Login struct {
+	User      string
+	SessionID string
+	Roles     map[string]bool
+	Reply     *actor.PID
+}
+
+func TestRender(t *testing.T) {
+	a := assert.New(t)
+
+	client := actor.NewLocalPID("client$12")
+	server := actor.NewLocalPID("$7")
+	other := actor.NewLocalPID("$8")
+	child := actor.NewLocalPID("$7/worker$13")
+
+	history := []*catcher.Entry{
+		{Seq: 1, Kind: catcher.KindUserInbound, Envelope: &catcher.Envelope{Target: client, Message: "start"}},
+		{Seq: 2, Kind: catcher.KindUserOutbound, Envelope: &catcher.Envelope{Sender: client, Target: server, Message: &Login{
+			User:      "bob",
+			SessionID: "d41d8cd9",
+			Roles:     map[string]bool{"write": false, "read": true},
+			Reply:     client,
+		}}},
+		{Seq: 3, Kind: catcher.KindSpawning, Envelope: &catcher.Envelope{Sender: server, Target: child}},
+		{Seq: 4, Kind: catcher.KindUserOutbound, Envelope: &catcher.Envelope{Sender: server, Target: other, Message: []int{1, 2}}},
+	}
+
+	a.Equal(`unknown -> client: "start"
+client -> actor: &snapshot_test.Login{User:"bob" SessionID:<redacted> Roles:map["read":true "write":false] Reply:client}
+note over actor: spawned actor/worker
+actor -> actor#2: [1 2]
+`, snapshot.Render(trace.New(history), "SessionID"))
+}
+
+func TestMatch(t *testing.T) {
+	a := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "gopactor-snapshot")
+	a.Nil(err)
+	defer os.RemoveAll(dir)
+
+	snapshot.GOLDEN_DIR = dir
+	defer func() { snapshot.GOLDEN_DIR = "testdata" }()
+
+	// A missing snapshot is a failure
+	err = snapshot.Match("login", "a -> b: 1\n")
+	if a.NotNil(err) {
+		a.Contains(err.Error(), "does not exist")
+	}
+
+	// Updating writes the golden file
+	a.Nil(os.Setenv(snapshot.UPDATE_ENV, "1"))
+	a.Nil(snapshot.Match("login", "a -> b: 1\n"))
+	a.Nil(os.Unsetenv(snapshot.UPDATE_ENV))
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "login.golden"))
+	a.Nil(err)
+	a.Equal("a -> b: 1\n", string(content))
+
+	// Then the conversation is compared with it
+	a.Nil(snapshot.Match("login", "a -> b: 1\n"))
+
+	err = snapshot.Match("login", "a -> b: 2\n")
+	if a.NotNil(err) {
+		a.Contains(err.Error(), "(line 1)")
+	}
+
+	// The flag of the tests works as well
+	*update = true
+	defer func() { *update = false }()
+	a.Nil(snapshot.Match("login", "a -> b: 2\n"))
+
+	content, err = ioutil.ReadFile(filepath.Join(dir, "login.golden"))
+	a.Nil(err)
+	a.Equal("a -> b: 2\n", string(content))
+}
@@ -0,0 +1,46 @@
+package gopactor
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+type Login struct {
+	User      string
+	SessionID string
+}
+
+func TestShouldMatchConversationSnapshot(t *testing.T) {
+	a := assert.New(t)
+
+	opt := OptDefault.WithJournaling().WithTimeout(time.Second)
+	server, _ := SpawnFromFunc(func(ctx actor.Context) {
+		if login, ok := ctx.Message().(*Login); ok {
+			ctx.Respond("welcome " + login.User)
+		}
+	}, opt.WithPrefix("server"))
+	client, _ := SpawnFromFunc(func(ctx actor.Context) {
+		if ctx.Message() == "start" {
+			ctx.Request(server, &Login{User: "bob", SessionID: fmt.Sprint(time.Now().UnixNano())})
+		}
+	}, opt.WithPrefix("client"))
+
+	client.Tell("start")
+	a.Empty(ShouldEventuallyReceive(client, "welcome bob"))
+
+	// Session IDs differ from run to run
+	a.Empty(ShouldMatchConversationSnapshot([]*actor.PID{client, server}, "login", "SessionID"))
+
+	// Wrong parameters
+	a.Equal("Object is not an actor PID or a list of actor PIDs", ShouldMatchConversationSnapshot("client", "login"))
+	a.Equal("One parameter with a snapshot name is required", ShouldMatchConversationSnapshot(client))
+	a.Equal("Snapshot name should be a string", ShouldMatchConversationSnapshot(client, 1))
+	a.Equal("Redacted fields should be strings", ShouldMatchConversationSnapshot(client, "login", 1))
+
+	// Cleanup
+	PactReset()
+}
@@ -1,9 +1,11 @@
 package gopactor
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/stub"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -67,3 +69,121 @@ func TestSpawnNullActor(t *testing.T) {
 	// Cleanup
 	PactReset()
 }
+
+type Writer struct{}
+
+func (w *Writer) Receive(ctx actor.Context) {
+	if _, ok := ctx.Message().(string); ok {
+		ctx.Respond("real")
+	}
+}
+
+func TestSpawn_StubbedChildren(t *testing.T) {
+	a := assert.New(t)
+
+	writer := stub.New().On("write", "ack")
+
+	parent := func(spawn func(ctx actor.Context) *actor.PID) actor.ActorFunc {
+		var child *actor.PID
+		return func(ctx actor.Context) {
+			switch msg := ctx.Message().(type) {
+			case *actor.Started:
+				child = spawn(ctx)
+			case string:
+				if msg == "go" {
+					ctx.Request(child, "write")
+				} else {
+					ctx.Tell(ctx.Sender(), "got "+msg)
+				}
+			}
+		}
+	}
+
+	bySpawnPrefix := parent(func(ctx actor.Context) *actor.PID {
+		return ctx.SpawnPrefix(actor.FromInstance(&Writer{}), "db-writer-")
+	})
+	bySpawnNamed := parent(func(ctx actor.Context) *actor.PID {
+		pid, _ := ctx.SpawnNamed(actor.FromInstance(&Writer{}), "db-writer-1")
+		return pid
+	})
+	bySpawn := parent(func(ctx actor.Context) *actor.PID {
+		return ctx.Spawn(actor.FromInstance(&Writer{}))
+	})
+
+	// Substitution by name
+	opt := OptOutboundInterceptionOnly.WithStubbedChild("db-writer-*", writer)
+	for _, f := range []actor.ActorFunc{bySpawnPrefix, bySpawnNamed} {
+		p, _ := SpawnFromFunc(f, opt)
+		p.Tell("go")
+		a.Empty(ShouldSend(p, "write"))
+		a.Empty(ShouldSend(p, "got ack"))
+	}
+
+	// Children spawned without a name can not be matched by name
+	p, _ := SpawnFromFunc(bySpawn, opt.WithRealSpawning())
+	p.Tell("go")
+	a.Empty(ShouldSend(p, "write"))
+	a.Empty(ShouldSend(p, "got real"))
+
+	// Substitution by actor type
+	opt = OptOutboundInterceptionOnly.WithStubbedActor(&Writer{}, writer)
+	for _, f := range []actor.ActorFunc{bySpawnPrefix, bySpawnNamed, bySpawn} {
+		p, _ := SpawnFromFunc(f, opt)
+		p.Tell("go")
+		a.Empty(ShouldSend(p, "write"))
+		a.Empty(ShouldSend(p, "got ack"))
+	}
+
+	// Other children are still dummies
+	p, _ = SpawnFromFunc(parent(func(ctx actor.Context) *actor.PID {
+		return ctx.Spawn(actor.FromFunc(func(ctx actor.Context) { ctx.Respond("real") }))
+	}), opt)
+	p.Tell("go")
+	a.Empty(ShouldSend(p, "write"))
+	a.Contains(ShouldSend(p, "got real"), "Timeout")
+
+	// Cleanup
+	PactReset()
+}
+
+func TestSpawn_RecursiveInterception(t *testing.T) {
+	a := assert.New(t)
+
+	// Every actor in the tree down to the great-grandchild spawns
+	// a child named "child" and forwards all messages to it
+	var node actor.ActorFunc
+	node = func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case *actor.Started:
+			if strings.Count(ctx.Self().Id, "/child") < 3 {
+				ctx.SpawnNamed(actor.FromFunc(node), "child")
+			}
+		case string:
+			for _, child := range ctx.Children() {
+				ctx.Request(child, msg)
+			}
+		}
+	}
+
+	root, _ := SpawnFromFunc(node, OptDefault.WithRealSpawning().WithRecursiveInterception(2))
+	child := actor.NewLocalPID(root.Id + "/child")
+	grandchild := actor.NewLocalPID(child.Id + "/child")
+	greatGrandchild := actor.NewLocalPID(grandchild.Id + "/child")
+
+	root.Tell("hello")
+	a.Empty(ShouldReceive(root, "hello"))
+	a.Empty(ShouldSendTo(root, child, "hello"))
+
+	// Children and grandchildren are intercepted with the same options
+	a.Empty(ShouldReceiveFrom(child, root, "hello"))
+	a.Empty(ShouldSendTo(child, grandchild, "hello"))
+	a.Empty(ShouldReceiveFrom(grandchild, child, "hello"))
+	a.Empty(ShouldSendTo(grandchild, greatGrandchild, "hello"))
+
+	// But the depth is limited
+	a.Contains(ShouldReceive(greatGrandchild, "hello"), "not registered")
+
+	// Cleanup
+	root.Stop()
+	PactReset()
+}
@@ -0,0 +1,95 @@
+// Package stub provides scripted actors to stand in for real children
+// of the tested actor. A stub replies to the messages it expects
+// with predefined responses and records everything it receives.
+//
+// Example:
+//
+//   writer := stub.New().On(&Write{}, &Ack{})
+//   opt := OptDefault.WithStubbedChild("db-writer-*", writer)
+//   parent, _ := SpawnFromInstance(&Parent{}, opt)
+//
+//   // Whenever the parent sends Write to a "db-writer-*" child, it gets Ack back.
+package stub
+
+import (
+	"sync"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/matchers"
+)
+
+// Stub is a scripted actor.
+// It can safely serve several children at the same time.
+type Stub struct {
+	mu       sync.Mutex
+	rules    []*rule
+	received []interface{}
+}
+
+type rule struct {
+	expected interface{}
+	replies  []interface{}
+}
+
+// New creates a stub that does nothing but record received messages.
+func New() *Stub {
+	return &Stub{}
+}
+
+// On tells the stub to respond with the replies, in order,
+// whenever it receives a message matching the expected one.
+// The expected message can be a matcher. Rules are checked
+// in the order they are added, and the first matching rule wins.
+// Replies are sent to the sender of the message. Messages without
+// a sender are only recorded.
+func (s *Stub) On(expected interface{}, replies ...interface{}) *Stub {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rules = append(s.rules, &rule{expected, replies})
+	return s
+}
+
+// Props returns the props to spawn the stub as a regular actor.
+func (s *Stub) Props() *actor.Props {
+	return actor.FromInstance(s)
+}
+
+// Receive implements actor.Actor.
+func (s *Stub) Receive(ctx actor.Context) {
+	msg := ctx.Message()
+	switch msg.(type) {
+	case actor.SystemMessage, actor.AutoReceiveMessage:
+		return
+	}
+
+	s.mu.Lock()
+	s.received = append(s.received, msg)
+	replies := s.repliesTo(msg)
+	s.mu.Unlock()
+
+	if ctx.Sender() == nil {
+		return
+	}
+
+	for _, reply := range replies {
+		ctx.Respond(reply)
+	}
+}
+
+// Received returns all user messages received by the stub in order.
+func (s *Stub) Received() []interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]interface{}(nil), s.received...)
+}
+
+func (s *Stub) repliesTo(msg interface{}) []interface{} {
+	for _, rule := range s.rules {
+		if matchers.Match(msg, rule.expected) {
+			return rule.replies
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package stub_test
+
+import (
+	"testing"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/fake"
+	"github.com/meamidos/gopactor/matchers"
+	"github.com/meamidos/gopactor/stub"
+	"github.com/stretchr/testify/assert"
+)
+
+type Write struct{ Key string }
+type Ack struct{}
+
+func TestStub(t *testing.T) {
+	a := assert.New(t)
+
+	s := stub.New().
+		On(&Write{Key: "bad"}, "nope").
+		On(matchers.OfType(&Write{}), &Ack{}, "and more")
+
+	ctx := fake.NewContext(s)
+	ctx.Start()
+	requestor := actor.NewLocalPID("requestor")
+
+	// The first matching rule wins
+	ctx.ReceiveFrom(requestor, &Write{Key: "bad"})
+	a.Equal([]interface{}{"nope"}, ctx.Responses())
+	ctx.ClearCalls()
+
+	// All replies are sent in order
+	ctx.ReceiveFrom(requestor, &Write{Key: "good"})
+	a.Equal([]interface{}{&Ack{}, "and more"}, ctx.Responses())
+	ctx.ClearCalls()
+
+	// No rule, no reply
+	ctx.ReceiveFrom(requestor, "unknown")
+	a.Empty(ctx.Calls())
+
+	// No sender, no reply
+	ctx.Receive(&Write{Key: "good"})
+	a.Empty(ctx.Calls())
+
+	// Only user messages are recorded
+	a.Equal([]interface{}{
+		&Write{Key: "bad"},
+		&Write{Key: "good"},
+		"unknown",
+		&Write{Key: "good"},
+	}, s.Received())
+}
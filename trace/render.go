@@ -0,0 +1,69 @@
+package trace
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// PlantUML renders the trace as a PlantUML sequence diagram.
+func (t *Trace) PlantUML() string {
+	var b bytes.Buffer
+	aliases := t.aliases()
+
+	b.WriteString("@startuml\n")
+	for i, name := range t.Participants() {
+		fmt.Fprintf(&b, "participant %q as P%d\n", name, i+1)
+	}
+
+	for _, event := range t.Events {
+		from := aliases[participant(event.From)]
+		if event.IsNote() {
+			fmt.Fprintf(&b, "note over %s: %s\n", from, event.Note)
+		} else {
+			fmt.Fprintf(&b, "%s -> %s: %s\n", from, aliases[participant(event.To)], Label(event.Message))
+		}
+	}
+	b.WriteString("@enduml\n")
+
+	return b.String()
+}
+
+// Mermaid renders the trace as a Mermaid sequence diagram.
+func (t *Trace) Mermaid() string {
+	var b bytes.Buffer
+	aliases := t.aliases()
+
+	b.WriteString("sequenceDiagram\n")
+	for i, name := range t.Participants() {
+		fmt.Fprintf(&b, "    participant P%d as %s\n", i+1, escapeMermaid(name))
+	}
+
+	for _, event := range t.Events {
+		from := aliases[participant(event.From)]
+		if event.IsNote() {
+			fmt.Fprintf(&b, "    Note over %s: %s\n", from, escapeMermaid(event.Note))
+		} else {
+			fmt.Fprintf(&b, "    %s->>%s: %s\n", from, aliases[participant(event.To)], escapeMermaid(Label(event.Message)))
+		}
+	}
+
+	return b.String()
+}
+
+func (t *Trace) aliases() map[string]string {
+	aliases := map[string]string{}
+	for i, name := range t.Participants() {
+		aliases[name] = fmt.Sprintf("P%d", i+1)
+	}
+
+	return aliases
+}
+
+// Mermaid treats semicolons and hashes in text as syntax.
+// They are replaced with entity codes.
+var mermaidReplacer = strings.NewReplacer("#", "#35;", ";", "#59;")
+
+func escapeMermaid(text string) string {
+	return mermaidReplacer.Replace(text)
+}
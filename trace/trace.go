@@ -0,0 +1,202 @@
+// Package trace turns the envelopes intercepted by catchers into
+// a conversation among actors, in order, and renders it as a sequence diagram.
+// Spawns, stops, restarts and failures appear as notes.
+//
+// Example:
+//
+//   p := gopactor.New()
+//   // ... spawn actors and run the scenario ...
+//   fmt.Println(p.Trace().PlantUML())
+//   fmt.Println(p.Trace().Mermaid())
+package trace
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/catcher"
+)
+
+// UNKNOWN_SENDER is the participant name for messages without a sender,
+// e.g. messages sent by the test itself.
+const UNKNOWN_SENDER = "unknown"
+
+// Event is either a message sent from one actor to another,
+// or a note about a single actor.
+type Event struct {
+	Seq     uint64
+	From    *actor.PID // Nil for messages without a sender
+	To      *actor.PID
+	Message interface{}
+
+	// Notes have no message. A note may mention another actor,
+	// e.g. a spawned child. Its PID appears in the text as well.
+	Note  string
+	About *actor.PID
+}
+
+// IsNote tells whether the event is a note about the From actor
+func (e *Event) IsNote() bool {
+	return e.Note != ""
+}
+
+// Trace is an ordered list of events.
+type Trace struct {
+	Events []*Event
+}
+
+// New builds a trace from the histories of catchers.
+// A message intercepted by both the sender and the receiver appears once.
+func New(histories ...[]*catcher.Entry) *Trace {
+	entries := []*catcher.Entry{}
+	for _, history := range histories {
+		entries = append(entries, history...)
+	}
+
+	sort.Sort(bySeq(entries))
+
+	t := &Trace{}
+	sent := []*catcher.Envelope{}
+
+	for _, entry := range entries {
+		envelope := entry.Envelope
+
+		switch entry.Kind {
+		case catcher.KindUserOutbound, catcher.KindSystemOutbound:
+			sent = append(sent, envelope)
+			t.addMessage(entry.Seq, envelope)
+
+		case catcher.KindUserInbound:
+			if !consume(&sent, envelope) {
+				t.addMessage(entry.Seq, envelope)
+			}
+
+		case catcher.KindSystemInbound:
+			switch msg := envelope.Message.(type) {
+			case *actor.Watch, *actor.Unwatch, *actor.PoisonPill:
+				if !consume(&sent, envelope) {
+					t.addMessage(entry.Seq, envelope)
+				}
+			case *actor.Stopped:
+				t.addNote(entry.Seq, envelope.Target, nil, "stopped")
+			case *actor.Restarting:
+				t.addNote(entry.Seq, envelope.Target, nil, "restarting")
+			case *actor.Failure:
+				if msg.Who.Equal(envelope.Target) {
+					t.addNote(entry.Seq, envelope.Target, nil, fmt.Sprintf("failed: %v", msg.Reason))
+				} else {
+					t.addNote(entry.Seq, envelope.Target, msg.Who, fmt.Sprintf("child %v failed: %v", msg.Who, msg.Reason))
+				}
+			}
+
+		case catcher.KindSpawning:
+			t.addNote(entry.Seq, envelope.Sender, envelope.Target, fmt.Sprintf("spawned %v", envelope.Target))
+
+		case catcher.KindSupervision:
+			t.addNote(entry.Seq, envelope.Sender, envelope.Target, fmt.Sprintf("%v", envelope.Message))
+		}
+	}
+
+	return t
+}
+
+func (t *Trace) addMessage(seq uint64, envelope *catcher.Envelope) {
+	t.Events = append(t.Events, &Event{
+		Seq:     seq,
+		From:    envelope.Sender,
+		To:      envelope.Target,
+		Message: envelope.Message,
+	})
+}
+
+func (t *Trace) addNote(seq uint64, pid *actor.PID, about *actor.PID, note string) {
+	t.Events = append(t.Events, &Event{
+		Seq:   seq,
+		From:  pid,
+		Note:  note,
+		About: about,
+	})
+}
+
+type bySeq []*catcher.Entry
+
+func (s bySeq) Len() int           { return len(s) }
+func (s bySeq) Less(i, j int) bool { return s[i].Seq < s[j].Seq }
+func (s bySeq) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// consume removes the first sent envelope which has been received
+// as the given one. Messages sent with Tell are received without a sender.
+func consume(sent *[]*catcher.Envelope, received *catcher.Envelope) bool {
+	for i, envelope := range *sent {
+		if received.Sender != nil && !received.Sender.Equal(envelope.Sender) {
+			continue
+		}
+
+		if received.Target.Equal(envelope.Target) && reflect.DeepEqual(received.Message, envelope.Message) {
+			*sent = append((*sent)[:i], (*sent)[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// Participants returns the names of all actors involved,
+// in order of their first appearance.
+func (t *Trace) Participants() []string {
+	names := []string{}
+	seen := map[string]bool{}
+
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	for _, event := range t.Events {
+		add(participant(event.From))
+		if !event.IsNote() {
+			add(participant(event.To))
+		}
+	}
+
+	return names
+}
+
+func participant(pid *actor.PID) string {
+	if pid == nil {
+		return UNKNOWN_SENDER
+	}
+
+	return pid.String()
+}
+
+// Label returns a short single-line description of a message.
+func Label(msg interface{}) string {
+	if msg == nil {
+		return "nil"
+	}
+
+	if s, ok := msg.(string); ok {
+		return strconv.Quote(s)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(msg))
+	if !value.IsValid() {
+		return fmt.Sprintf("%T(nil)", msg)
+	}
+
+	var label string
+	if value.Kind() == reflect.Struct {
+		label = fmt.Sprintf("%T%+v", msg, value.Interface())
+	} else {
+		label = fmt.Sprintf("%T(%v)", msg, value.Interface())
+	}
+
+	return strings.Replace(label, "\n", " ", -1)
+}
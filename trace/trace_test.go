@@ -0,0 +1,83 @@
+package trace_test
+
+import (
+	"testing"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/catcher"
+	"github.com/meamidos/gopactor/trace"
+	"github.com/stretchr/testify/assert"
+)
+
+type Ping struct{ N int }
+
+func TestTrace(t *testing.T) {
+	a := assert.New(t)
+
+	client := actor.NewLocalPID("client")
+	server := actor.NewLocalPID("server")
+	child := actor.NewLocalPID("server/child")
+
+	clientHistory := []*catcher.Entry{
+		{Seq: 2, Kind: catcher.KindUserOutbound, Envelope: &catcher.Envelope{Sender: client, Target: server, Message: &Ping{N: 1}}},
+		{Seq: 7, Kind: catcher.KindUserInbound, Envelope: &catcher.Envelope{Sender: server, Target: client, Message: "pong"}},
+	}
+	serverHistory := []*catcher.Entry{
+		{Seq: 1, Kind: catcher.KindUserInbound, Envelope: &catcher.Envelope{Target: server, Message: "hello; #1"}},
+		{Seq: 3, Kind: catcher.KindUserInbound, Envelope: &catcher.Envelope{Target: server, Message: &Ping{N: 1}}},
+		{Seq: 4, Kind: catcher.KindSpawning, Envelope: &catcher.Envelope{Sender: server, Target: child}},
+		{Seq: 5, Kind: catcher.KindSystemInbound, Envelope: &catcher.Envelope{Sender: child, Target: server, Message: &actor.Failure{Who: child, Reason: "boom"}}},
+		{Seq: 6, Kind: catcher.KindUserOutbound, Envelope: &catcher.Envelope{Sender: server, Target: client, Message: "pong"}},
+		{Seq: 8, Kind: catcher.KindSystemInbound, Envelope: &catcher.Envelope{Target: server, Message: &actor.Stopped{}}},
+		{Seq: 9, Kind: catcher.KindSystemInbound, Envelope: &catcher.Envelope{Target: server, Message: &actor.Started{}}},
+	}
+
+	tr := trace.New(clientHistory, serverHistory)
+
+	// Messages seen by both sides appear once, in order
+	a.Len(tr.Events, 6)
+	a.Nil(tr.Events[0].From)
+	a.Equal(uint64(2), tr.Events[1].Seq)
+	a.Equal("spawned "+child.String(), tr.Events[2].Note)
+	a.Equal("child "+child.String()+" failed: boom", tr.Events[3].Note)
+	a.Equal("stopped", tr.Events[5].Note)
+	a.Equal([]string{trace.UNKNOWN_SENDER, server.String(), client.String()}, tr.Participants())
+
+	a.Equal(`@startuml
+participant "unknown" as P1
+participant "`+server.String()+`" as P2
+participant "`+client.String()+`" as P3
+P1 -> P2: "hello; #1"
+P3 -> P2: *trace_test.Ping{N:1}
+note over P2: spawned `+child.String()+`
+note over P2: child `+child.String()+` failed: boom
+P2 -> P3: "pong"
+note over P2: stopped
+@enduml
+`, tr.PlantUML())
+
+	a.Equal(`sequenceDiagram
+    participant P1 as unknown
+    participant P2 as `+server.String()+`
+    participant P3 as `+client.String()+`
+    P1->>P2: "hello#59; #35;1"
+    P3->>P2: *trace_test.Ping{N:1}
+    Note over P2: spawned `+child.String()+`
+    Note over P2: child `+child.String()+` failed: boom
+    P2->>P3: "pong"
+    Note over P2: stopped
+`, tr.Mermaid())
+}
+
+func TestLabel(t *testing.T) {
+	a := assert.New(t)
+
+	var nilPing *Ping
+
+	a.Equal(`"hello"`, trace.Label("hello"))
+	a.Equal("int(42)", trace.Label(42))
+	a.Equal("*trace_test.Ping{N:1}", trace.Label(&Ping{N: 1}))
+	a.Equal("trace_test.Ping{N:2}", trace.Label(Ping{N: 2}))
+	a.Equal("*trace_test.Ping(nil)", trace.Label(nilPing))
+	a.Equal("nil", trace.Label(nil))
+}
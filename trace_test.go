@@ -0,0 +1,76 @@
+package gopactor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AsynkronIT/protoactor-go/actor"
+	"github.com/meamidos/gopactor/gopactor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrace(t *testing.T) {
+	a := assert.New(t)
+
+	opt := OptDefault.WithJournaling().WithTimeout(time.Second)
+	server, _ := SpawnFromFunc(func(ctx actor.Context) {
+		if ctx.Message() == "ping" {
+			ctx.Respond("pong")
+		}
+	}, opt)
+	client, _ := SpawnFromFunc(func(ctx actor.Context) {
+		if ctx.Message() == "start" {
+			ctx.Request(server, "ping")
+		}
+	}, opt)
+
+	client.Tell("start")
+	a.Empty(ShouldEventuallyReceive(client, "pong"))
+
+	// Every message is intercepted by both actors, but appears once
+	diagram := Trace().PlantUML()
+	a.Contains(diagram, `P1 -> P2: "start"`)
+	a.Contains(diagram, `P2 -> P3: "ping"`)
+	a.Contains(diagram, `P3 -> P2: "pong"`)
+	a.Len(Trace().Events, 3)
+
+	// Cleanup
+	PactReset()
+}
+
+func TestForTest_TraceOnFailure(t *testing.T) {
+	a := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "gopactor-trace")
+	a.Nil(err)
+	defer os.RemoveAll(dir)
+
+	gopactor.TRACE_DIR = dir
+	defer func() { gopactor.TRACE_DIR = "" }()
+
+	// A passing test leaves no trace
+	ft := &fakeT{}
+	p := ForTest(ft)
+	worker, _ := p.SpawnNullActor(OptDefault.WithJournaling())
+	worker.Tell("hello")
+	ft.cleanups[0]()
+	a.Empty(ft.logs)
+
+	// A failed test leaves both diagrams
+	ft = &fakeT{failed: true}
+	p = ForTest(ft)
+	worker, _ = p.SpawnNullActor(OptDefault.WithJournaling())
+	worker.Tell("hello")
+	a.Empty(p.ShouldReceive(worker, "hello"))
+	ft.cleanups[0]()
+
+	puml := filepath.Join(dir, "TestFake_with_spaces.puml")
+	a.Equal([]string{"Gopactor trace: " + puml, "Gopactor trace: " + filepath.Join(dir, "TestFake_with_spaces.mmd")}, ft.logs)
+
+	content, err := ioutil.ReadFile(puml)
+	a.Nil(err)
+	a.Contains(string(content), `"hello"`)
+}